@@ -0,0 +1,135 @@
+package debounce
+
+import (
+	"sync"
+	"time"
+)
+
+// Activity tracks bursts of calls: onStart is called on the first call after a period
+// of inactivity and onEnd when the burst settles, by the same rules, that make the
+// debounced function execute (delay, WithMaxCalls, WithMaxWait, ...).
+//
+// Callbacks are never run concurrently and always alternate, starting with onStart.
+// onStart is run by Do before it returns, unless a previous callback is still running,
+// then it is run right after it. onEnd is executed like a debounced function.
+type Activity struct {
+	d       *debouncer
+	active  bool // Guarded by d.mu
+	onStart func()
+	onEnd   func()
+
+	// Callbacks waiting to be run, queued under d.mu, so they are run in order of bursts.
+	mu      sync.Mutex
+	queue   []func()
+	running bool
+	idle    sync.Cond // Signaled, when running callbacks stops
+}
+
+// NewActivity creates an Activity, that considers a burst over after a period of inactivity
+// of after duration. Either callback may be nil.
+func NewActivity(after time.Duration, onStart, onEnd func(), options ...Option) *Activity {
+	a := &Activity{
+		d:       newDebouncer(after, options...),
+		onStart: onStart,
+		onEnd:   onEnd,
+	}
+	a.idle.L = &a.mu
+
+	// Called under d.mu, when the burst settles
+	a.d.take = func() func() {
+		a.end()
+		return a.run
+	}
+
+	return a
+}
+
+// Do records a call. It starts a burst, if there is no active one, and extends it otherwise.
+func (a *Activity) Do() {
+	d := a.d
+
+	d.mu.Lock()
+	start := !d.stopped && d.calls == 0
+	if start {
+		a.active = true
+		a.push(a.onStart)
+	}
+	f := d.record(time.Now())
+	d.mu.Unlock()
+
+	if start {
+		a.run()
+	}
+	if f != nil {
+		d.execute(f) // Execute outside mutex to avoid blocking
+	}
+}
+
+// Active reports whether a burst is in progress: it has started, but not settled yet.
+func (a *Activity) Active() bool {
+	a.d.mu.Lock()
+	defer a.d.mu.Unlock()
+	return a.active
+}
+
+// Stop ends the active burst, if any, and ignores calls made after it.
+// Stop returns after all callbacks, including onEnd of the active burst, have returned,
+// so it must not be called from the callbacks.
+func (a *Activity) Stop() {
+	a.d.stop()
+
+	a.d.mu.Lock()
+	a.end()
+	a.d.mu.Unlock()
+
+	a.run()
+
+	// Callbacks could be run by another goroutine
+	a.mu.Lock()
+	for a.running || len(a.queue) > 0 {
+		a.idle.Wait()
+	}
+	a.mu.Unlock()
+}
+
+// end marks the active burst as settled and queues onEnd.
+// Must be called with d.mu held.
+func (a *Activity) end() {
+	if a.active {
+		a.active = false
+		a.push(a.onEnd)
+	}
+}
+
+// push queues a callback. Must be called with d.mu held.
+func (a *Activity) push(fn func()) {
+	if fn == nil {
+		return
+	}
+	a.mu.Lock()
+	a.queue = append(a.queue, fn)
+	a.mu.Unlock()
+}
+
+// run runs queued callbacks one by one, unless they are already run by another goroutine.
+// Must be called without d.mu held.
+func (a *Activity) run() {
+	a.mu.Lock()
+	if a.running {
+		a.mu.Unlock()
+		return
+	}
+	a.running = true
+	for len(a.queue) > 0 {
+		fn := a.queue[0]
+		a.queue[0] = nil
+		a.queue = a.queue[1:]
+
+		a.mu.Unlock()
+		fn()
+		a.mu.Lock()
+	}
+	a.running = false
+	a.idle.Broadcast()
+	a.mu.Unlock()
+}
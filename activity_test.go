@@ -0,0 +1,178 @@
+package debounce
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestActivity(t *testing.T) {
+	var mu sync.Mutex
+	var events []string
+	record := func(event string) func() {
+		return func() {
+			mu.Lock()
+			events = append(events, event)
+			mu.Unlock()
+		}
+	}
+
+	activity := NewActivity(50*time.Millisecond, record("start"), record("end"))
+	defer activity.Stop()
+
+	if activity.Active() {
+		t.Error("Expected activity to be idle before the first call")
+	}
+
+	activity.Do()
+	if !activity.Active() {
+		t.Error("Expected activity to be active after the first call")
+	}
+
+	// onStart is called before Do returns
+	mu.Lock()
+	if !reflect.DeepEqual(events, []string{"start"}) {
+		t.Errorf("Expected [start] after the first call, got %v", events)
+	}
+	mu.Unlock()
+
+	for i := 0; i < 5; i++ {
+		time.Sleep(10 * time.Millisecond)
+		activity.Do()
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if activity.Active() {
+		t.Error("Expected activity to be idle after the burst settled")
+	}
+
+	activity.Do()
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(events, []string{"start", "end", "start", "end"}) {
+		t.Errorf("Expected two bursts, got %v", events)
+	}
+}
+
+func TestActivityMaxCalls(t *testing.T) {
+	var starts, ends int
+	var mu sync.Mutex
+	activity := NewActivity(time.Second, func() {
+		mu.Lock()
+		starts++
+		mu.Unlock()
+	}, func() {
+		mu.Lock()
+		ends++
+		mu.Unlock()
+	}, WithMaxCalls(2), WithExecutor(Inline))
+	defer activity.Stop()
+
+	for i := 0; i < 4; i++ {
+		activity.Do()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if starts != 2 || ends != 2 {
+		t.Errorf("Expected 2 bursts by MaxCalls, got %d starts and %d ends", starts, ends)
+	}
+	if activity.Active() {
+		t.Error("Expected activity to be idle after MaxCalls was reached")
+	}
+}
+
+func TestActivityStop(t *testing.T) {
+	ended := false
+	activity := NewActivity(time.Second, nil, func() { ended = true })
+
+	activity.Do()
+	activity.Stop()
+
+	if !ended {
+		t.Error("Expected Stop to end the active burst")
+	}
+	if activity.Active() {
+		t.Error("Expected activity to be idle after Stop")
+	}
+
+	activity.Do()
+	if activity.Active() {
+		t.Error("Expected calls after Stop to be ignored")
+	}
+}
+
+func TestActivityStopWaitsForCallbacks(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	ended := false
+	activity := NewActivity(time.Second, func() {
+		close(started)
+		<-release
+	}, func() {
+		mu.Lock()
+		ended = true
+		mu.Unlock()
+	})
+
+	go activity.Do()
+	<-started
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+	}()
+	activity.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !ended {
+		t.Error("Expected Stop to wait for onEnd run by another goroutine")
+	}
+}
+
+func TestActivityConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	var events []bool // true for start, false for end
+	activity := NewActivity(time.Millisecond, func() {
+		mu.Lock()
+		events = append(events, true)
+		mu.Unlock()
+	}, func() {
+		mu.Lock()
+		events = append(events, false)
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				activity.Do()
+				if j%10 == 0 {
+					time.Sleep(2 * time.Millisecond)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	activity.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events)%2 != 0 {
+		t.Errorf("Expected every burst to end, got %d callbacks", len(events))
+	}
+	for i, start := range events {
+		if start != (i%2 == 0) {
+			t.Fatalf("Expected callbacks to alternate, got %v", events)
+		}
+	}
+}
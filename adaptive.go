@@ -0,0 +1,60 @@
+package debounce
+
+import "time"
+
+// adaptiveAlpha is the smoothing factor of the moving average of gaps between calls.
+const adaptiveAlpha = 0.2
+
+// adaptive tracks an exponentially weighted moving average (EWMA) of gaps between
+// calls and derives the quiet period from it.
+type adaptive struct {
+	factor   float64
+	min, max time.Duration
+
+	avg  float64   // Average gap in nanoseconds, zero until the first gap is observed
+	last time.Time // Time of the previous call in the pending window
+}
+
+// WithAdaptiveDelay replaces the fixed after duration with a quiet period derived
+// from the observed call rate: factor times the moving average of gaps between calls,
+// clamped between min and max. Only gaps inside a pending window are sampled, so idle
+// time between bursts does not inflate the delay. Until the first gap is observed
+// the after duration (clamped the same way) is used.
+func WithAdaptiveDelay(factor float64, min, max time.Duration) Option {
+	return func(d *debouncer) {
+		d.adaptive = &adaptive{
+			factor: factor,
+			min:    min,
+			max:    max,
+		}
+	}
+}
+
+// observe records a call made at now. pending reports whether the call continues
+// an already pending window.
+func (a *adaptive) observe(now time.Time, pending bool) {
+	if pending {
+		gap := float64(now.Sub(a.last))
+		if a.avg == 0 {
+			a.avg = gap
+		} else {
+			a.avg += adaptiveAlpha * (gap - a.avg)
+		}
+	}
+	a.last = now
+}
+
+// delay returns the quiet period to use, falling back to after if no gap was observed yet.
+func (a *adaptive) delay(after time.Duration) time.Duration {
+	d := after
+	if a.avg != 0 {
+		d = time.Duration(a.factor * a.avg)
+	}
+	if d < a.min {
+		d = a.min
+	}
+	if d > a.max {
+		d = a.max
+	}
+	return d
+}
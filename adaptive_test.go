@@ -0,0 +1,65 @@
+package debounce
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveDelayFunction(t *testing.T) {
+	a := &adaptive{factor: 2, min: 10 * time.Millisecond, max: 100 * time.Millisecond}
+
+	if d := a.delay(50 * time.Millisecond); d != 50*time.Millisecond {
+		t.Errorf("Expected fallback delay 50ms, got %v", d)
+	}
+
+	if d := a.delay(time.Second); d != 100*time.Millisecond {
+		t.Errorf("Expected fallback delay to be clamped to 100ms, got %v", d)
+	}
+
+	start := time.Now()
+	a.observe(start, false)
+	a.observe(start.Add(20*time.Millisecond), true)
+
+	if d := a.delay(time.Second); d != 40*time.Millisecond {
+		t.Errorf("Expected delay 40ms after first gap, got %v", d)
+	}
+
+	// Idle gaps between windows must not be sampled
+	a.observe(start.Add(time.Hour), false)
+	if d := a.delay(time.Second); d != 40*time.Millisecond {
+		t.Errorf("Expected delay to stay 40ms after idle gap, got %v", d)
+	}
+
+	a.observe(start.Add(time.Hour+time.Millisecond), true)
+	if d := a.delay(time.Second); d <= 10*time.Millisecond || d >= 40*time.Millisecond {
+		t.Errorf("Expected delay between 10ms and 40ms after short gap, got %v", d)
+	}
+}
+
+func TestWithAdaptiveDelay(t *testing.T) {
+	var called int
+	var mu sync.Mutex
+
+	debounced := New(200*time.Millisecond, WithAdaptiveDelay(3, 20*time.Millisecond, 200*time.Millisecond))
+
+	fn := func() {
+		mu.Lock()
+		called++
+		mu.Unlock()
+	}
+
+	// Calls spaced by ~10ms should shrink quiet period to ~30ms
+	for i := 0; i < 5; i++ {
+		debounced(fn)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+
+	mu.Lock()
+	if called != 1 {
+		t.Errorf("Expected 1 call, got %d", called)
+	}
+	mu.Unlock()
+}
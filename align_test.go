@@ -0,0 +1,56 @@
+package debounce
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAlignFunction(t *testing.T) {
+	base := time.Unix(1000, 0)
+
+	cases := []struct {
+		t      time.Time
+		period time.Duration
+		offset time.Duration
+		want   time.Time
+	}{
+		{base.Add(3 * time.Second), 10 * time.Second, 0, base.Add(10 * time.Second)},
+		{base, 10 * time.Second, 0, base},
+		{base.Add(3 * time.Second), 10 * time.Second, 5 * time.Second, base.Add(5 * time.Second)},
+		{base.Add(7 * time.Second), 10 * time.Second, 5 * time.Second, base.Add(15 * time.Second)},
+		{base.Add(3 * time.Second), 0, 0, base.Add(3 * time.Second)},
+	}
+
+	for _, c := range cases {
+		if got := align(c.t, c.period, c.offset); !got.Equal(c.want) {
+			t.Errorf("Expected align(%v, %v, %v) = %v, got %v", c.t.Unix(), c.period, c.offset, c.want.Unix(), got.Unix())
+		}
+	}
+}
+
+func TestWithAlign(t *testing.T) {
+	control := NewControl(10*time.Millisecond, WithAlign(100*time.Millisecond, 0))
+	defer control.Stop()
+
+	control.Do(func() {})
+
+	s := control.State()
+	if s.FireAt.UnixNano()%int64(100*time.Millisecond) != 0 {
+		t.Errorf("Expected fire time aligned to 100ms, got %v", s.FireAt)
+	}
+	if s.FireAt.Sub(s.First) < 10*time.Millisecond {
+		t.Errorf("Expected fire time after the quiet period, got %v", s.FireAt.Sub(s.First))
+	}
+
+	done := make(chan time.Time, 1)
+	control.Do(func() { done <- time.Now() })
+
+	select {
+	case fired := <-done:
+		if fired.Before(s.FireAt) {
+			t.Errorf("Expected execution not before %v, got %v", s.FireAt, fired)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected function to be executed")
+	}
+}
@@ -0,0 +1,81 @@
+package debounce
+
+import "time"
+
+// NewFunc1 returns a debounced function that calls fn with the argument of the last call.
+// Unlike capturing arguments in closures passed to New, the argument is stored
+// under the debouncer lock, so no extra synchronization is needed.
+func NewFunc1[A any](fn func(A), after time.Duration, options ...Option) func(A) {
+	d := newDebouncer(after, options...)
+
+	var last A // Guarded by d.mu
+	d.take = func() func() {
+		a := last
+		var zero A
+		last = zero // Do not retain the argument until the next window
+		return func() { fn(a) }
+	}
+
+	return func(a A) {
+		d.mu.Lock()
+		last = a
+		f := d.record(time.Now())
+		d.mu.Unlock()
+
+		if f != nil {
+			d.execute(f) // Execute outside mutex to avoid blocking
+		}
+	}
+}
+
+// NewFunc2 is like NewFunc1, but for functions with two arguments.
+func NewFunc2[A, B any](fn func(A, B), after time.Duration, options ...Option) func(A, B) {
+	d := newDebouncer(after, options...)
+
+	var lastA A // Guarded by d.mu
+	var lastB B // Guarded by d.mu
+	d.take = func() func() {
+		a, b := lastA, lastB
+		var zeroA A
+		var zeroB B
+		lastA, lastB = zeroA, zeroB // Do not retain the arguments until the next window
+		return func() { fn(a, b) }
+	}
+
+	return func(a A, b B) {
+		d.mu.Lock()
+		lastA, lastB = a, b
+		f := d.record(time.Now())
+		d.mu.Unlock()
+
+		if f != nil {
+			d.execute(f) // Execute outside mutex to avoid blocking
+		}
+	}
+}
+
+// NewFuncMerge returns a debounced function that calls fn with arguments of all calls
+// in the window, in the order of calls. fn owns the slice, it is not reused.
+func NewFuncMerge[A any](fn func([]A), after time.Duration, options ...Option) func(A) {
+	d := newDebouncer(after, options...)
+
+	var args []A // Guarded by d.mu
+	d.take = func() func() {
+		merged := args
+		args = nil
+		return func() { fn(merged) }
+	}
+
+	return func(a A) {
+		d.mu.Lock()
+		if !d.stopped {
+			args = append(args, a)
+		}
+		f := d.record(time.Now())
+		d.mu.Unlock()
+
+		if f != nil {
+			d.execute(f) // Execute outside mutex to avoid blocking
+		}
+	}
+}
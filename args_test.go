@@ -0,0 +1,88 @@
+package debounce
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewFunc1(t *testing.T) {
+	var mu sync.Mutex
+	var got []int
+
+	debounced := NewFunc1(func(v int) {
+		mu.Lock()
+		got = append(got, v)
+		mu.Unlock()
+	}, 50*time.Millisecond)
+
+	debounced(1)
+	debounced(2)
+	debounced(3)
+
+	time.Sleep(100 * time.Millisecond)
+
+	debounced(4)
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(got, []int{3, 4}) {
+		t.Errorf("Expected last arguments [3 4], got %v", got)
+	}
+}
+
+func TestNewFunc2(t *testing.T) {
+	done := make(chan string, 1)
+
+	debounced := NewFunc2(func(key string, v int) {
+		done <- key + "=" + strconv.Itoa(v)
+	}, 10*time.Millisecond, WithMaxCalls(2))
+
+	debounced("a", 1)
+	debounced("b", 2) // MaxCalls reached
+
+	select {
+	case got := <-done:
+		if got != "b=2" {
+			t.Errorf("Expected b=2, got %s", got)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Error("Expected function to be called")
+	}
+}
+
+func TestNewFuncMerge(t *testing.T) {
+	done := make(chan []int, 2)
+
+	debounced := NewFuncMerge(func(vs []int) {
+		done <- vs
+	}, 50*time.Millisecond, WithMaxCalls(3))
+
+	for i := 1; i <= 5; i++ {
+		debounced(i)
+	}
+
+	// MaxCalls flushes the first three, the rest settle after the delay
+	for _, expected := range [][]int{{1, 2, 3}, {4, 5}} {
+		select {
+		case got := <-done:
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected %v, got %v", expected, got)
+			}
+		case <-time.After(200 * time.Millisecond):
+			t.Errorf("Expected %v to be merged", expected)
+		}
+	}
+}
+
+func TestNewFunc1Allocations(t *testing.T) {
+	debounced := NewFunc1(func(int) {}, time.Hour)
+
+	if allocs := testing.AllocsPerRun(100, func() { debounced(1) }); allocs != 0 {
+		t.Errorf("Expected no allocations per call, got %v", allocs)
+	}
+}
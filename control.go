@@ -0,0 +1,61 @@
+package debounce
+
+import "time"
+
+// Control is a handle to a live debouncer. Unlike the function returned by New,
+// it allows changing the debouncer configuration after it was created.
+type Control struct {
+	d *debouncer
+}
+
+// NewControl creates a debouncer the same way as New does and returns a handle to it.
+func NewControl(after time.Duration, options ...Option) *Control {
+	return &Control{d: newDebouncer(after, options...)}
+}
+
+// Do debounces fn, exactly like the function returned by New.
+func (c *Control) Do(fn func()) {
+	c.d.debouncedCall(fn)
+}
+
+// DoBy debounces fn like Do, but the pending window fires no later than deadline,
+// even if calls keep coming. Coalesced calls keep the earliest deadline among them.
+func (c *Control) DoBy(fn func(), deadline time.Time) {
+	c.d.debouncedCallBy(fn, deadline)
+}
+
+// Func returns a debounced function that always debounces fn, like NewFunc.
+func (c *Control) Func(fn func()) func() {
+	return func() {
+		c.d.debouncedCall(fn)
+	}
+}
+
+// SetOptions atomically applies options (WithDelay, WithMaxCalls, WithMaxWait, ...)
+// to the live debouncer.
+//
+// A pending call keeps its call count and the time of its first call. If the new
+// MaxCalls or MaxWait limits are already reached, it is executed immediately.
+// Otherwise its timer is restarted with the new delay, counted from the moment
+// of reconfiguration.
+func (c *Control) SetOptions(options ...Option) {
+	c.d.setOptions(options...)
+}
+
+// Pause holds execution of debounced functions. Calls are still accepted and
+// coalesced, but delay, MaxCalls and MaxWait triggers are suppressed until Resume.
+func (c *Control) Pause() {
+	c.d.setPaused(true)
+}
+
+// Resume ends a pause. If any calls were made in the meantime, the last function
+// is executed immediately, once.
+func (c *Control) Resume() {
+	c.d.setPaused(false)
+}
+
+// Stop cancels the pending call, if any, and unregisters the debouncer from its Registry.
+// Calls made after Stop are ignored.
+func (c *Control) Stop() {
+	c.d.stop()
+}
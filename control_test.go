@@ -0,0 +1,191 @@
+package debounce
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestControlDo(t *testing.T) {
+	var called int
+	var mu sync.Mutex
+
+	control := NewControl(50 * time.Millisecond)
+
+	fn := func() {
+		mu.Lock()
+		called++
+		mu.Unlock()
+	}
+
+	control.Func(fn)()
+	control.Do(fn)
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	if called != 1 {
+		t.Errorf("Expected 1 call, got %d", called)
+	}
+	mu.Unlock()
+}
+
+func TestControlDoBy(t *testing.T) {
+	done := make(chan time.Time, 1)
+	control := NewControl(100 * time.Millisecond)
+	defer control.Stop()
+
+	start := time.Now()
+	control.DoBy(func() { done <- time.Now() }, start.Add(50*time.Millisecond))
+
+	// Calls keep coming, but the window still fires by the deadline
+	for i := 0; i < 10; i++ {
+		time.Sleep(10 * time.Millisecond)
+		select {
+		case fired := <-done:
+			if elapsed := fired.Sub(start); elapsed < 50*time.Millisecond || elapsed > 80*time.Millisecond {
+				t.Errorf("Expected execution at the deadline, got after %v", elapsed)
+			}
+			return
+		default:
+		}
+		control.Do(func() { done <- time.Now() })
+	}
+	t.Error("Expected execution by the deadline")
+}
+
+func TestControlSetOptionsDelay(t *testing.T) {
+	var called int
+	var mu sync.Mutex
+
+	control := NewControl(500 * time.Millisecond)
+
+	control.Do(func() {
+		mu.Lock()
+		called++
+		mu.Unlock()
+	})
+
+	// Shortening the delay applies to the pending call
+	control.SetOptions(WithDelay(50 * time.Millisecond))
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	if called != 1 {
+		t.Errorf("Expected 1 call, got %d", called)
+	}
+	mu.Unlock()
+}
+
+func TestControlSetOptionsLimits(t *testing.T) {
+	var called int
+	var mu sync.Mutex
+
+	control := NewControl(500 * time.Millisecond)
+
+	fn := func() {
+		mu.Lock()
+		called++
+		mu.Unlock()
+	}
+
+	control.Do(fn)
+	control.Do(fn)
+	control.Do(fn)
+
+	// Pending window already has 3 calls, so it is executed immediately
+	control.SetOptions(WithMaxCalls(3))
+
+	time.Sleep(10 * time.Millisecond)
+
+	mu.Lock()
+	if called != 1 {
+		t.Errorf("Expected 1 call, got %d", called)
+	}
+	mu.Unlock()
+
+	control.Do(fn)
+	time.Sleep(10 * time.Millisecond)
+	control.SetOptions(WithMaxWait(5 * time.Millisecond))
+	time.Sleep(10 * time.Millisecond)
+
+	mu.Lock()
+	if called != 2 {
+		t.Errorf("Expected 2 calls, got %d", called)
+	}
+	mu.Unlock()
+
+	// Reconfiguring idle debouncer does nothing
+	control.SetOptions(WithDelay(10 * time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+
+	mu.Lock()
+	if called != 2 {
+		t.Errorf("Expected 2 calls, got %d", called)
+	}
+	mu.Unlock()
+}
+
+func TestControlPauseResume(t *testing.T) {
+	var called int
+	var mu sync.Mutex
+
+	control := NewControl(20*time.Millisecond, WithMaxCalls(2))
+
+	fn := func() {
+		mu.Lock()
+		called++
+		mu.Unlock()
+	}
+
+	control.Do(fn)
+	control.Pause()
+	control.Do(fn)
+	control.Do(fn)
+
+	// Neither delay nor MaxCalls trigger while paused
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	if called != 0 {
+		t.Errorf("Expected 0 calls, got %d", called)
+	}
+	mu.Unlock()
+
+	control.Resume()
+	control.Resume()
+	time.Sleep(10 * time.Millisecond)
+
+	mu.Lock()
+	if called != 1 {
+		t.Errorf("Expected 1 call, got %d", called)
+	}
+	mu.Unlock()
+
+	// Resuming without pending calls executes nothing
+	control.Pause()
+	control.Resume()
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	if called != 1 {
+		t.Errorf("Expected 1 call, got %d", called)
+	}
+	mu.Unlock()
+}
+
+func TestControlStop(t *testing.T) {
+	called := make(chan struct{}, 1)
+	control := NewControl(10 * time.Millisecond)
+
+	control.Do(func() { called <- struct{}{} })
+	control.Stop()
+	control.Do(func() { called <- struct{}{} })
+
+	select {
+	case <-called:
+		t.Error("Expected no calls after Stop")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -9,7 +9,9 @@ package debounce
 
 import (
 	"math"
+	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -21,8 +23,17 @@ const (
 	NoLimitWait  = time.Duration(math.MaxInt64)
 )
 
+// WithDelay sets the period of inactivity after which the debounced function is executed.
+// It overrides the after argument and is mostly useful with Control.SetOptions.
+func WithDelay(after time.Duration) Option {
+	return func(d *debouncer) {
+		d.after = after
+	}
+}
+
 // WithMaxCalls sets the maximum number of calls before the debounced function is executed.
-// By default, there is no limit.
+// By default, there is no limit. Calls made concurrently with the one reaching the limit
+// may join the same execution.
 func WithMaxCalls(count int) Option {
 	return func(d *debouncer) {
 		d.maxCalls = count
@@ -44,30 +55,7 @@ func WithMaxWait(limit time.Duration) Option {
 // The debounced function can be invoked with different functions, if needed,
 // the last one will win.
 func New(after time.Duration, options ...Option) func(fn func()) {
-	d := &debouncer{
-		after:     after,
-		startWait: time.Now(),
-		maxWait:   NoLimitWait,
-		maxCalls:  NoLimitCalls,
-	}
-
-	// Creating timer and immediately stop it, so there will be always allocated Timer
-	d.timer = time.AfterFunc(NoLimitWait, func() {
-		d.mu.Lock()
-		if d.calls == 0 {
-			d.mu.Unlock()
-			return // MaxCalls or MaxWait reached, call can be dropped
-		}
-		d.calls = 0
-		d.mu.Unlock()
-
-		d.fn()
-	})
-	d.timer.Stop()
-
-	for _, opt := range options {
-		opt(d)
-	}
+	d := newDebouncer(after, options...)
 
 	return func(fn func()) {
 		d.debouncedCall(fn)
@@ -83,10 +71,55 @@ func NewFunc(fn func(), after time.Duration, options ...Option) func() {
 	}
 }
 
+func newDebouncer(after time.Duration, options ...Option) *debouncer {
+	now := time.Now()
+	d := &debouncer{
+		after:     after,
+		startWait: now,
+		maxWait:   NoLimitWait,
+		maxCalls:  NoLimitCalls,
+		epoch:     now,
+	}
+
+	for _, opt := range options {
+		opt(d)
+	}
+	d.created = true
+
+	// Creating timer and immediately stop it, so there will be always allocated Timer
+	if d.scheduler != nil {
+		d.timer = d.scheduler.afterFunc(func() {
+			if fn := d.expire(); fn != nil && d.executor == nil && d.limiter == nil {
+				d.execute(fn) // Executes in a new goroutine
+			} else if fn != nil {
+				go d.execute(fn) // Executor or Limiter could block the shared Scheduler goroutine
+			}
+		})
+	} else {
+		d.timer = time.AfterFunc(NoLimitWait, func() {
+			if fn := d.expire(); fn != nil && d.executor == nil && d.limiter == nil {
+				fn() // Runtime timer already has its own goroutine
+			} else if fn != nil {
+				d.execute(fn)
+			}
+		})
+		d.timer.Stop()
+	}
+
+	d.register()
+	return d
+}
+
+// timer is implemented by *time.Timer and timers of Scheduler.
+type timer interface {
+	Reset(d time.Duration) bool
+	Stop() bool
+}
+
 type debouncer struct {
 	mu    sync.Mutex
 	after time.Duration
-	timer *time.Timer
+	timer timer
 
 	calls    int
 	maxCalls int
@@ -94,8 +127,66 @@ type debouncer struct {
 	startWait time.Time
 	maxWait   time.Duration
 
+	// Lock-free path state. While open is set, the window is pending and its timer is armed,
+	// so a call only stores fn and quietFrom and counts itself in extra. Such calls
+	// do not reset the timer, instead it re-arms itself for the rest of the quiet period.
+	open      atomic.Bool
+	extra     atomic.Int64 // Calls made on the lock-free path, not yet added to calls
+	budget    atomic.Int64 // Calls on the lock-free path left before MaxCalls is reached
+	deadline  atomic.Int64 // Time MaxWait is reached, relative to epoch
+	quietFrom atomic.Int64 // Time the quiet period started, relative to epoch
+	epoch     time.Time
+
+	// Holds execution of the pending function until resumed.
+	paused bool
+
+	// Time the timer is armed for, zero if it is stopped, and total number of executions.
+	fireAt time.Time
+	fires  uint64
+
+	// Name and registry the debouncer is registered in, if configured with WithName.
+	name     string
+	registry *Registry
+
+	// Ignores all calls after Control.Stop.
+	stopped bool
+
+	// Set after options of the created debouncer are applied. Scheduler, executor and limiter
+	// are fixed at creation, so the timer can read them without d.mu.
+	created bool
+
+	// Drives the timer instead of runtime, if configured with WithScheduler.
+	scheduler *Scheduler
+
+	// Executes functions instead of new goroutines, if configured with WithExecutor.
+	executor Executor
+
+	// Caps simultaneous executions, if configured with WithLimiter.
+	limiter Semaphore
+
+	// Derives the quiet period from the call rate, if configured with WithAdaptiveDelay.
+	adaptive *adaptive
+
+	// Randomizes the quiet period of every window, if configured with WithJitter or WithJitterFraction.
+	jitter         time.Duration
+	jitterFraction float64
+	rand           *rand.Rand
+	windowJitter   time.Duration // Jitter of the pending window
+
+	// Rounds fire time up to clock boundaries, if configured with WithAlign.
+	alignPeriod time.Duration
+	alignOffset time.Duration
+
+	// Earliest deadline of calls in the pending window, zero if there is none.
+	callDeadline time.Time
+
 	// Stores last function to debounce. Will be called after specified duration.
-	fn func()
+	fn atomic.Value
+
+	// Returns function, that consumes arguments of the pending window, if created with
+	// NewFunc1, NewFunc2 or NewFuncMerge. Arguments are guarded by mu, so calls with
+	// arguments never take the lock-free path.
+	take func() func()
 }
 
 func (d *debouncer) callLimitReached() bool {
@@ -106,30 +197,338 @@ func (d *debouncer) timeLimitReached() bool {
 	return d.maxWait != NoLimitWait && time.Since(d.startWait) >= d.maxWait
 }
 
+// sync closes the lock-free path and adds calls made on it to d.calls, so budget
+// of the path never lags behind the count. schedule opens the path again.
+// Must be called with d.mu held.
+func (d *debouncer) sync() {
+	d.open.Store(false)
+	d.calls += int(d.extra.Swap(0))
+}
+
+// expire is called by the timer. It resets the window and returns the function to execute,
+// or nil if there is nothing to execute.
+func (d *debouncer) expire() func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Timer was stopped (MaxCalls or MaxWait reached, or debouncer is paused),
+	// when this call was on its way, so it can be dropped.
+	if d.fireAt.IsZero() {
+		return nil
+	}
+
+	// Calls on the lock-free path extended the quiet period
+	now := time.Now()
+	due := d.due()
+	if now.Before(due) {
+		d.fireAt = due
+		d.timer.Reset(due.Sub(now))
+		return nil
+	}
+
+	d.sync()
+
+	d.calls = 0
+	d.callDeadline = time.Time{}
+	d.fires++
+	d.fireAt = time.Time{}
+	return d.pendingFn()
+}
+
+// due returns the time the pending window fires: the quiet period after the last call,
+// rounded up to the next boundary, if configured with WithAlign, or the earliest
+// deadline of calls made with Control.DoBy, whichever comes first.
+func (d *debouncer) due() time.Time {
+	due := align(d.epoch.Add(time.Duration(d.quietFrom.Load())+d.delay()), d.alignPeriod, d.alignOffset)
+	if !d.callDeadline.IsZero() && d.callDeadline.Before(due) {
+		return d.callDeadline
+	}
+	return due
+}
+
+// pendingFn returns the function to execute for the pending window.
+// Must be called with d.mu held.
+func (d *debouncer) pendingFn() func() {
+	if d.take != nil {
+		return d.take()
+	}
+	return d.fn.Load().(func())
+}
+
+// delay returns the quiet period of the pending window.
+func (d *debouncer) delay() time.Duration {
+	if d.adaptive != nil {
+		return d.adaptive.delay(d.after) + d.windowJitter
+	}
+	return d.after + d.windowJitter
+}
+
+// flush resets the window and returns the pending function to be executed outside of the timer.
+// Must be called with d.mu held.
+func (d *debouncer) flush() func() {
+	d.sync()
+
+	d.timer.Stop() // Stop the timer to prevent it from firing later
+	d.calls = 0
+	d.callDeadline = time.Time{}
+	d.fires++
+	d.fireAt = time.Time{}
+	return d.pendingFn()
+}
+
+// drain returns the pending function regardless of delay and limits, or nil if there is none.
+func (d *debouncer) drain() func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sync()
+	if d.calls == 0 {
+		return nil
+	}
+	return d.flush()
+}
+
+// execute runs fn with the configured Executor, or in a new goroutine by default.
+// If Limiter is configured, fn waits for a free slot first.
+// Must be called without d.mu held.
+func (d *debouncer) execute(fn func()) {
+	if d.limiter != nil {
+		d.limiter.Acquire(d, func() {
+			d.dispatch(func() {
+				defer d.limiter.Release()
+				fn()
+			})
+		})
+	} else {
+		d.dispatch(fn)
+	}
+}
+
+func (d *debouncer) dispatch(fn func()) {
+	if d.executor != nil {
+		d.executor.Submit(fn)
+	} else {
+		go fn()
+	}
+}
+
+// schedule restarts the timer with the quiet period of the pending window
+// and opens the lock-free path for the following calls.
+// Must be called with d.mu held.
+func (d *debouncer) schedule(now time.Time) {
+	d.quietFrom.Store(int64(now.Sub(d.epoch)))
+	d.fireAt = d.due()
+	d.timer.Reset(d.fireAt.Sub(now))
+
+	// Adaptive delay has to observe every call and arguments need d.mu
+	if d.adaptive != nil || d.take != nil {
+		return
+	}
+
+	budget, deadline := int64(math.MaxInt64), int64(math.MaxInt64)
+	if d.maxCalls != NoLimitCalls {
+		budget = int64(d.maxCalls - d.calls)
+	}
+	if start := int64(d.startWait.Sub(d.epoch)); d.maxWait != NoLimitWait && int64(d.maxWait) < math.MaxInt64-start {
+		deadline = start + int64(d.maxWait)
+	}
+
+	d.budget.Store(budget)
+	d.deadline.Store(deadline)
+	d.open.Store(true)
+}
+
+// setOptions applies options to the live debouncer and re-evaluates the pending window.
+func (d *debouncer) setOptions(options ...Option) {
+	if fn := d.reconfigure(options...); fn != nil {
+		d.execute(fn) // Execute outside mutex to avoid blocking
+	}
+}
+
+func (d *debouncer) reconfigure(options ...Option) func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, opt := range options {
+		opt(d)
+	}
+
+	d.sync()
+	if d.calls == 0 || d.paused {
+		return nil
+	}
+
+	if d.callLimitReached() || d.timeLimitReached() {
+		return d.flush()
+	}
+
+	d.schedule(time.Now())
+	return nil
+}
+
+// setPaused pauses or resumes execution. Resuming executes the pending function immediately.
+func (d *debouncer) setPaused(paused bool) {
+	if fn := d.pause(paused); fn != nil {
+		d.execute(fn) // Execute outside mutex to avoid blocking
+	}
+}
+
+func (d *debouncer) pause(paused bool) func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.paused == paused {
+		return nil
+	}
+
+	d.paused = paused
+	d.sync()
+	if paused {
+		d.timer.Stop()
+		d.fireAt = time.Time{}
+	} else if d.calls > 0 {
+		return d.flush()
+	}
+	return nil
+}
+
+// stop drops the pending call, ignores further calls and unregisters the debouncer.
+func (d *debouncer) stop() {
+	d.mu.Lock()
+	d.stopped = true
+	d.open.Store(false)
+	d.timer.Stop()
+	d.extra.Store(0)
+	d.calls = 0
+	d.callDeadline = time.Time{}
+	d.fireAt = time.Time{}
+	d.mu.Unlock()
+
+	if d.registry != nil {
+		d.registry.unregister(d)
+	}
+}
+
 func (d *debouncer) debouncedCall(fn func()) {
+	// Lock-free path: the call continues a pending window, that does not hit any limit.
+	if d.open.Load() {
+		now := int64(time.Since(d.epoch))
+		d.fn.Store(fn) // Stored before counting, so whoever takes the count sees fn
+		d.quietFrom.Store(now)
+		calls := d.extra.Add(1)
+		if d.open.Load() && calls < d.budget.Load() && now < d.deadline.Load() {
+			return
+		}
+
+		// Limit is reached or window was closed concurrently
+		if fn := d.settle(); fn != nil {
+			d.execute(fn) // Execute outside mutex to avoid blocking
+		}
+		return
+	}
+
+	if fn := d.call(fn); fn != nil {
+		d.execute(fn) // Execute outside mutex to avoid blocking
+	}
+}
+
+// debouncedCallBy is debouncedCall, that also makes the pending window fire no later than deadline.
+func (d *debouncer) debouncedCallBy(fn func(), deadline time.Time) {
+	if fn := d.callBy(fn, deadline); fn != nil {
+		d.execute(fn) // Execute outside mutex to avoid blocking
+	}
+}
+
+func (d *debouncer) callBy(fn func(), deadline time.Time) func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.fn.Store(fn)
+	if d.callDeadline.IsZero() || deadline.Before(d.callDeadline) {
+		d.callDeadline = deadline
+	}
+	return d.record(time.Now())
+}
+
+// call records the call and returns the function to be executed immediately, if any.
+func (d *debouncer) call(fn func()) func() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
 	// Refreshing function reference, so d.timer will call right function
-	d.fn = fn
+	d.fn.Store(fn)
+	return d.record(time.Now())
+}
+
+// record counts a call made at now and returns the function to be executed immediately, if any.
+// Must be called with d.mu held.
+func (d *debouncer) record(now time.Time) func() {
+	if d.stopped {
+		return nil
+	}
+
+	d.sync()
 
 	// If this is a first call, store startWait time
 	if d.calls == 0 {
-		d.startWait = time.Now()
+		d.startWait = now
+		d.windowJitter = d.drawJitter()
+	}
+
+	if d.adaptive != nil {
+		d.adaptive.observe(now, d.calls > 0)
 	}
 
 	// Counting calls
 	d.calls++
 
+	return d.evaluate(now)
+}
+
+// settle re-evaluates the window after a call on the lock-free path, that could not
+// complete there. It returns the function to be executed immediately, if any.
+func (d *debouncer) settle() func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sync()
+	if d.stopped {
+		d.calls = 0
+		d.callDeadline = time.Time{}
+		return nil
+	}
+
+	// Call was already taken by the window, that closed concurrently
+	if d.calls == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	// Call started a new window
+	if d.fireAt.IsZero() && !d.paused {
+		d.startWait = now
+		d.windowJitter = d.drawJitter()
+	}
+
+	return d.evaluate(now)
+}
+
+// evaluate checks limits of the pending window after a call.
+// Must be called with d.mu held.
+func (d *debouncer) evaluate(now time.Time) func() {
+	// Paused debouncer only remembers the call, it will be executed on resume
+	if d.paused {
+		return nil
+	}
+
 	// If the function has been called more than the limit, or if the wait time
 	// has exceeded the limit, execute the function immediately.
 	if d.callLimitReached() || d.timeLimitReached() {
-		d.timer.Stop() // Stop the timer to prevent it from firing later
-		d.calls = 0
-		fn := d.fn
-		go fn() // Execute outside mutex to avoid blocking
-	} else {
-		// Restarting timer, if limits were ok
-		d.timer.Reset(d.after)
+		return d.flush()
 	}
+
+	// Restarting timer, if limits were ok
+	d.schedule(now)
+	return nil
 }
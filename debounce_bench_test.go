@@ -205,6 +205,52 @@ func BenchmarkScaling(b *testing.B) {
 	}
 }
 
+// BenchmarkKeyedScaling tests performance of keyed calls with different numbers of goroutines
+func BenchmarkKeyedScaling(b *testing.B) {
+	goroutineCounts := []int{1, 2, 4, 8, 16, 32, 64, 128}
+	hash := func(k int) uint64 { return uint64(k) }
+
+	keys := make([]int, 1024)
+	for i := range keys {
+		keys[i] = i
+	}
+
+	for _, bc := range []struct {
+		name string
+		new  func() func(int, func())
+	}{
+		{"keyed", func() func(int, func()) { return NewKeyed[int](100 * time.Millisecond).Do }},
+		{"sharded", func() func(int, func()) { return NewSharded(100*time.Millisecond, 64, hash).Do }},
+	} {
+		for _, numGoroutines := range goroutineCounts {
+			b.Run(fmt.Sprintf("%s/goroutines-%d", bc.name, numGoroutines), func(b *testing.B) {
+				do := bc.new()
+				fn := func() {}
+				for _, key := range keys {
+					do(key, fn)
+				}
+
+				b.ResetTimer()
+				b.ReportAllocs()
+
+				var wg sync.WaitGroup
+				callsPerGoroutine := b.N / numGoroutines
+
+				for i := 0; i < numGoroutines; i++ {
+					wg.Add(1)
+					go func(offset int) {
+						defer wg.Done()
+						for j := 0; j < callsPerGoroutine; j++ {
+							do(keys[(offset+j)%len(keys)], fn)
+						}
+					}(i * 97)
+				}
+				wg.Wait()
+			})
+		}
+	}
+}
+
 // BenchmarkWithVariousDurations tests performance with different debounce durations
 func BenchmarkWithVariousDurations(b *testing.B) {
 	durations := []time.Duration{
@@ -309,3 +355,65 @@ func BenchmarkComparison(b *testing.B) {
 		}
 	})
 }
+
+// BenchmarkLiveDebouncers measures memory and per-call cost with 10^6 live debouncers,
+// each holding a pending call, with runtime timers and with a shared Scheduler
+func BenchmarkLiveDebouncers(b *testing.B) {
+	const count = 1_000_000
+
+	fn := func() {}
+
+	// Debouncers are created once per sub-benchmark, as it is called for every b.N
+	setup := func(options ...Option) (debounced []func(func()), bytes float64) {
+		var m1, m2 runtime.MemStats
+
+		runtime.GC()
+		runtime.ReadMemStats(&m1)
+
+		debounced = make([]func(func()), count)
+		for i := range debounced {
+			debounced[i] = New(time.Minute, options...)
+			debounced[i](fn)
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&m2)
+
+		return debounced, float64(m2.HeapAlloc-m1.HeapAlloc) / count
+	}
+
+	run := func(b *testing.B, debounced []func(func()), bytes float64) {
+		b.ResetTimer()
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			debounced[i%count](fn)
+		}
+
+		b.ReportMetric(bytes, "bytes/debouncer")
+	}
+
+	var timers []func(func())
+	var timersBytes float64
+
+	b.Run("timer", func(b *testing.B) {
+		if timers == nil {
+			timers, timersBytes = setup()
+		}
+		run(b, timers, timersBytes)
+	})
+	timers = nil
+
+	s := NewScheduler(time.Millisecond)
+	defer s.Stop()
+
+	var scheduled []func(func())
+	var scheduledBytes float64
+
+	b.Run("scheduler", func(b *testing.B) {
+		if scheduled == nil {
+			scheduled, scheduledBytes = setup(WithScheduler(s))
+		}
+		run(b, scheduled, scheduledBytes)
+	})
+}
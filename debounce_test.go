@@ -2,6 +2,7 @@ package debounce
 
 import (
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -281,6 +282,59 @@ func TestConcurrentCalls(t *testing.T) {
 	mu.Unlock()
 }
 
+func TestConcurrentCallsWithMaxCalls(t *testing.T) {
+	const goroutines, calls, maxCalls = 10, 1000, 10
+	control := NewControl(time.Second, WithMaxCalls(maxCalls))
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				control.Do(func() {})
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Concurrent calls can join the window while MaxCalls flush is on its way,
+	// but at most one call per goroutine
+	s := control.State()
+	if lo, hi := uint64(goroutines*calls/(maxCalls+goroutines)), uint64(goroutines*calls/maxCalls); s.Fires < lo || s.Fires > hi {
+		t.Errorf("Expected between %d and %d fires, got %d", lo, hi, s.Fires)
+	}
+	if s.Calls >= maxCalls {
+		t.Errorf("Expected less than %d pending calls, got %d", maxCalls, s.Calls)
+	}
+	control.Stop()
+}
+
+func TestCallsExtendQuietPeriod(t *testing.T) {
+	var called int32
+
+	debounced := New(30 * time.Millisecond)
+	fn := func() {
+		atomic.AddInt32(&called, 1)
+	}
+
+	// Calls after the first one do not reset the timer, it re-arms itself instead
+	for i := 0; i < 10; i++ {
+		debounced(fn)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if n := atomic.LoadInt32(&called); n != 0 {
+		t.Errorf("Expected no calls during the burst, got %d", n)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if n := atomic.LoadInt32(&called); n != 1 {
+		t.Errorf("Expected 1 call, got %d", n)
+	}
+}
+
 func TestResetBehavior(t *testing.T) {
 	var called int
 	var mu sync.Mutex
@@ -0,0 +1,139 @@
+package debounce
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInlineExecutor(t *testing.T) {
+	done := make(chan struct{})
+	var executed int32
+
+	debounced := New(time.Second, WithMaxCalls(1), WithExecutor(Inline))
+	debounced(func() {
+		atomic.AddInt32(&executed, 1)
+		close(done)
+	})
+
+	// MaxCalls fires on the calling goroutine
+	if atomic.LoadInt32(&executed) != 1 {
+		t.Error("Expected function to be executed synchronously")
+	}
+	<-done
+}
+
+func TestPool(t *testing.T) {
+	pool := NewPool(2, 10, QueueBlock)
+
+	var running, maxRunning, executed int32
+	for i := 0; i < 10; i++ {
+		pool.Submit(func() {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&executed, 1)
+		})
+	}
+	pool.Close()
+
+	if executed != 10 {
+		t.Errorf("Expected 10 executions, got %d", executed)
+	}
+	if maxRunning > 2 {
+		t.Errorf("Expected at most 2 concurrent executions, got %d", maxRunning)
+	}
+}
+
+func TestPoolQueuePolicies(t *testing.T) {
+	block := make(chan struct{})
+	started := make(chan struct{})
+
+	discard := NewPool(1, 1, QueueDiscard)
+	discard.Submit(func() { close(started); <-block })
+	<-started
+
+	var executed int32
+	discard.Submit(func() { atomic.AddInt32(&executed, 1) }) // Queued
+	discard.Submit(func() { atomic.AddInt32(&executed, 1) }) // Discarded
+
+	callerRuns := NewPool(1, 1, QueueCallerRuns)
+	started = make(chan struct{})
+	callerRuns.Submit(func() { close(started); <-block })
+	<-started
+	callerRuns.Submit(func() {}) // Queued
+
+	var ranInCaller bool
+	callerRuns.Submit(func() { ranInCaller = true })
+	if !ranInCaller {
+		t.Error("Expected function to be executed by caller, when queue is full")
+	}
+
+	close(block)
+	discard.Close()
+	callerRuns.Close()
+
+	if executed != 1 {
+		t.Errorf("Expected 1 execution, got %d", executed)
+	}
+}
+
+func TestWithExecutor(t *testing.T) {
+	serial := NewSerial(10)
+
+	var wg sync.WaitGroup
+	var running, maxRunning int32
+
+	debounced := make([]func(func()), 5)
+	for i := range debounced {
+		debounced[i] = New(10*time.Millisecond, WithExecutor(serial))
+	}
+
+	wg.Add(len(debounced))
+	for i := range debounced {
+		debounced[i](func() {
+			defer wg.Done()
+			if n := atomic.AddInt32(&running, 1); n > atomic.LoadInt32(&maxRunning) {
+				atomic.StoreInt32(&maxRunning, n)
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		})
+	}
+
+	wg.Wait()
+	serial.Close()
+
+	if maxRunning != 1 {
+		t.Errorf("Expected serial executions, got %d concurrent", maxRunning)
+	}
+}
+
+func TestWithExecutorSetOptions(t *testing.T) {
+	done := make(chan struct{}, 100)
+	c := NewControl(time.Millisecond)
+	defer c.Stop()
+
+	// Executor is fixed at creation, changing it must not race with the timer
+	for i := 0; i < 100; i++ {
+		c.Do(func() { done <- struct{}{} })
+		c.SetOptions(WithExecutor(Inline), WithLimiter(NewLimiter(1)))
+		time.Sleep(100 * time.Microsecond)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(100 * time.Millisecond):
+		t.Error("Expected function to be called")
+	}
+	if c.d.executor != nil || c.d.limiter != nil {
+		t.Error("Expected executor and limiter to be ignored after creation")
+	}
+}
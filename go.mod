@@ -1 +1,3 @@
 module github.com/floatdrop/debounce
+
+go 1.19
@@ -0,0 +1,44 @@
+package debounce
+
+import (
+	"math/rand"
+	"time"
+)
+
+// WithJitter adds a random duration in [0, jitter) to the quiet period of every window,
+// so debouncers with the same after duration, triggered at once, do not fire in lockstep.
+func WithJitter(jitter time.Duration) Option {
+	return func(d *debouncer) {
+		d.jitter = jitter
+	}
+}
+
+// WithJitterFraction is like WithJitter, but the bound is the given fraction of the after duration.
+func WithJitterFraction(fraction float64) Option {
+	return func(d *debouncer) {
+		d.jitterFraction = fraction
+	}
+}
+
+// WithRand sets the random source of jitter, for example a seeded one for reproducible tests.
+// The source is used under the debouncer lock, so it must not be shared with other debouncers.
+// By default, the global source of math/rand is used.
+func WithRand(r *rand.Rand) Option {
+	return func(d *debouncer) {
+		d.rand = r
+	}
+}
+
+// drawJitter returns a random addition to the quiet period of a new window.
+// Must be called with d.mu held.
+func (d *debouncer) drawJitter() time.Duration {
+	bound := int64(d.jitter + time.Duration(d.jitterFraction*float64(d.after)))
+	switch {
+	case bound <= 0:
+		return 0
+	case d.rand != nil:
+		return time.Duration(d.rand.Int63n(bound))
+	default:
+		return time.Duration(rand.Int63n(bound))
+	}
+}
@@ -0,0 +1,48 @@
+package debounce
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestDrawJitter(t *testing.T) {
+	d := newDebouncer(100*time.Millisecond, WithJitter(10*time.Millisecond), WithJitterFraction(0.1), WithRand(rand.New(rand.NewSource(1))))
+	expected := newDebouncer(100*time.Millisecond, WithJitter(10*time.Millisecond), WithJitterFraction(0.1), WithRand(rand.New(rand.NewSource(1))))
+
+	for i := 0; i < 100; i++ {
+		jitter := d.drawJitter()
+		if jitter < 0 || jitter >= 20*time.Millisecond {
+			t.Fatalf("Expected jitter in [0, 20ms), got %v", jitter)
+		}
+		if e := expected.drawJitter(); jitter != e {
+			t.Fatalf("Expected seeded jitter %v, got %v", e, jitter)
+		}
+	}
+
+	if jitter := newDebouncer(time.Second).drawJitter(); jitter != 0 {
+		t.Errorf("Expected no jitter by default, got %v", jitter)
+	}
+}
+
+func TestWithJitter(t *testing.T) {
+	control := NewControl(20*time.Millisecond, WithJitter(50*time.Millisecond), WithRand(rand.New(rand.NewSource(1))))
+
+	start := time.Now()
+	control.Do(func() {})
+
+	s := control.State()
+	delay := s.FireAt.Sub(start)
+	if delay < 20*time.Millisecond || delay >= 70*time.Millisecond+time.Millisecond {
+		t.Errorf("Expected fire in [20ms, 70ms), got %v", delay)
+	}
+
+	// Jitter is drawn once per window, so following calls keep it
+	time.Sleep(5 * time.Millisecond)
+	now := time.Now()
+	control.Do(func() {})
+	if s := control.State(); s.FireAt.Sub(now) < delay-time.Millisecond {
+		t.Errorf("Expected window jitter to be kept, got fire in %v", s.FireAt.Sub(now))
+	}
+	control.Stop()
+}
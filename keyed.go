@@ -0,0 +1,253 @@
+package debounce
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// EvictPolicy defines what happens to the pending call of an evicted key.
+type EvictPolicy int
+
+const (
+	// EvictFlush executes the pending function of the evicted key immediately. This is the default.
+	EvictFlush EvictPolicy = iota
+	// EvictDrop discards the pending function of the evicted key.
+	EvictDrop
+)
+
+// EvictReason tells why a key was evicted from Keyed.
+type EvictReason int
+
+const (
+	// EvictCapacity means the key was the least recently used one, when capacity was exceeded.
+	EvictCapacity EvictReason = iota
+	// EvictTTL means the key was not called for the configured TTL.
+	EvictTTL
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case EvictCapacity:
+		return "capacity"
+	case EvictTTL:
+		return "ttl"
+	default:
+		return "unknown"
+	}
+}
+
+// KeyedOption is a functional option for configuring Keyed with keys of type K.
+// Options, that do not take a key, are instantiated explicitly: WithCapacity[string](1000).
+type KeyedOption[K comparable] func(*keyedOptions[K])
+
+type keyedOptions[K comparable] struct {
+	capacity int
+	ttl      time.Duration
+	policy   EvictPolicy
+	hook     func(K, EvictReason)
+	options  []Option
+}
+
+// WithCapacity caps the number of keys tracked by Keyed. When a new key exceeds it,
+// the least recently used key is evicted. By default, there is no limit.
+func WithCapacity[K comparable](n int) KeyedOption[K] {
+	return func(o *keyedOptions[K]) {
+		o.capacity = n
+	}
+}
+
+// WithTTL evicts keys, that were not called for ttl. By default, keys are never expired.
+func WithTTL[K comparable](ttl time.Duration) KeyedOption[K] {
+	return func(o *keyedOptions[K]) {
+		o.ttl = ttl
+	}
+}
+
+// WithEvictPolicy sets what happens to the pending call of an evicted key.
+func WithEvictPolicy[K comparable](policy EvictPolicy) KeyedOption[K] {
+	return func(o *keyedOptions[K]) {
+		o.policy = policy
+	}
+}
+
+// WithEvictHook sets a function, that is called after a key is evicted.
+// The hook is called outside of Keyed lock.
+func WithEvictHook[K comparable](hook func(key K, reason EvictReason)) KeyedOption[K] {
+	return func(o *keyedOptions[K]) {
+		o.hook = hook
+	}
+}
+
+// WithKeyOptions sets options (WithMaxCalls, WithMaxWait, WithExecutor, ...) of every key debouncer.
+func WithKeyOptions[K comparable](options ...Option) KeyedOption[K] {
+	return func(o *keyedOptions[K]) {
+		o.options = options
+	}
+}
+
+// Keyed debounces calls of every key independently, each key with its own debouncer.
+// WithCapacity and WithTTL bound the memory used by keys, that are never called again.
+type Keyed[K comparable] struct {
+	mu      sync.Mutex
+	after   time.Duration
+	options keyedOptions[K]
+
+	keys    map[K]*keyedEntry[K]
+	lru     *list.List  // Entries by last call, most recent first
+	ttl     *time.Timer // Expires the least recently used entry, if configured WithTTL
+	ttlAt   time.Time   // Time ttl is armed for, zero if it is not armed
+	stopped bool
+}
+
+type keyedEntry[K comparable] struct {
+	key  K
+	d    *debouncer
+	elem *list.Element
+	last time.Time // Time of the last call
+}
+
+// NewKeyed creates a Keyed, that debounces calls of every key for after duration.
+func NewKeyed[K comparable](after time.Duration, options ...KeyedOption[K]) *Keyed[K] {
+	k := &Keyed[K]{
+		after: after,
+		keys:  make(map[K]*keyedEntry[K]),
+		lru:   list.New(),
+	}
+
+	for _, opt := range options {
+		opt(&k.options)
+	}
+
+	if k.options.ttl > 0 {
+		k.ttl = time.AfterFunc(NoLimitWait, k.expire)
+		k.ttl.Stop()
+	}
+
+	return k
+}
+
+// Do debounces fn for key.
+func (k *Keyed[K]) Do(key K, fn func()) {
+	var evicted *keyedEntry[K]
+	var flushed func()
+
+	k.mu.Lock()
+	if k.stopped {
+		k.mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+	e, ok := k.keys[key]
+	if ok {
+		k.lru.MoveToFront(e.elem)
+	} else {
+		if k.options.capacity > 0 && len(k.keys) >= k.options.capacity {
+			evicted = k.lru.Back().Value.(*keyedEntry[K])
+			flushed = k.evict(evicted)
+		}
+
+		e = &keyedEntry[K]{
+			key: key,
+			d:   newDebouncer(k.after, k.options.options...),
+		}
+		e.elem = k.lru.PushFront(e)
+		k.keys[key] = e
+	}
+	e.last = now
+
+	if k.ttl != nil && k.ttlAt.IsZero() {
+		k.ttlAt = now.Add(k.options.ttl)
+		k.ttl.Reset(k.options.ttl)
+	}
+
+	// Key debouncer is called under k.mu, so eviction can not miss the call
+	e.d.mu.Lock()
+	e.d.fn.Store(fn)
+	f := e.d.record(now)
+	e.d.mu.Unlock()
+	k.mu.Unlock()
+
+	if evicted != nil {
+		k.evicted(evicted, flushed, EvictCapacity)
+	}
+
+	if f != nil {
+		e.d.execute(f) // Execute outside mutex to avoid blocking
+	}
+}
+
+// Len returns the number of tracked keys.
+func (k *Keyed[K]) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.keys)
+}
+
+// Stop drops pending calls of all keys. Calls made after Stop are ignored.
+func (k *Keyed[K]) Stop() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.stopped = true
+	if k.ttl != nil {
+		k.ttl.Stop()
+	}
+	for key, e := range k.keys {
+		e.d.stop()
+		delete(k.keys, key)
+	}
+	k.lru.Init()
+}
+
+// expire evicts keys, that were not called for TTL, and re-arms the TTL timer.
+func (k *Keyed[K]) expire() {
+	var evicted []*keyedEntry[K]
+	var flushed []func()
+
+	k.mu.Lock()
+	now := time.Now()
+	k.ttlAt = time.Time{}
+	for back := k.lru.Back(); back != nil && !k.stopped; back = k.lru.Back() {
+		e := back.Value.(*keyedEntry[K])
+		if idle := now.Sub(e.last); idle < k.options.ttl {
+			k.ttlAt = e.last.Add(k.options.ttl)
+			k.ttl.Reset(k.options.ttl - idle)
+			break
+		}
+
+		evicted = append(evicted, e)
+		flushed = append(flushed, k.evict(e))
+	}
+	k.mu.Unlock()
+
+	for i, e := range evicted {
+		k.evicted(e, flushed[i], EvictTTL)
+	}
+}
+
+// evict removes e and returns its pending function, if it has to be flushed.
+// Must be called with k.mu held.
+func (k *Keyed[K]) evict(e *keyedEntry[K]) func() {
+	k.lru.Remove(e.elem)
+	delete(k.keys, e.key)
+
+	var fn func()
+	if k.options.policy == EvictFlush {
+		fn = e.d.drain()
+	}
+	e.d.stop()
+	return fn
+}
+
+// evicted executes flushed function of the evicted entry and reports eviction to the hook.
+// Must be called without k.mu held.
+func (k *Keyed[K]) evicted(e *keyedEntry[K], flushed func(), reason EvictReason) {
+	if flushed != nil {
+		e.d.execute(flushed)
+	}
+	if k.options.hook != nil {
+		k.options.hook(e.key, reason)
+	}
+}
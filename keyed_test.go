@@ -0,0 +1,137 @@
+package debounce
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKeyed(t *testing.T) {
+	var mu sync.Mutex
+	var called []string
+
+	keyed := NewKeyed[string](50 * time.Millisecond)
+	call := func(key string) func() {
+		return func() {
+			mu.Lock()
+			called = append(called, key)
+			mu.Unlock()
+		}
+	}
+
+	keyed.Do("a", call("a"))
+	keyed.Do("b", call("b"))
+	keyed.Do("a", call("a"))
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	sort.Strings(called)
+	if !reflect.DeepEqual(called, []string{"a", "b"}) {
+		t.Errorf("Expected one call per key, got %v", called)
+	}
+	mu.Unlock()
+
+	if n := keyed.Len(); n != 2 {
+		t.Errorf("Expected 2 keys, got %d", n)
+	}
+}
+
+func TestKeyedCapacity(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		policy   EvictPolicy
+		expected []string
+	}{
+		{"flush", EvictFlush, []string{"a"}},
+		{"drop", EvictDrop, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var called, evicted []string
+
+			keyed := NewKeyed[string](time.Second,
+				WithCapacity[string](2),
+				WithEvictPolicy[string](tc.policy),
+				WithKeyOptions[string](WithExecutor(Inline)),
+				WithEvictHook(func(key string, reason EvictReason) {
+					mu.Lock()
+					evicted = append(evicted, key+":"+reason.String())
+					mu.Unlock()
+				}),
+			)
+			defer keyed.Stop()
+
+			call := func(key string) func() {
+				return func() {
+					mu.Lock()
+					called = append(called, key)
+					mu.Unlock()
+				}
+			}
+
+			keyed.Do("a", call("a"))
+			keyed.Do("b", call("b"))
+			keyed.Do("c", call("c")) // a is the least recently used
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !reflect.DeepEqual(called, tc.expected) {
+				t.Errorf("Expected calls %v, got %v", tc.expected, called)
+			}
+			if !reflect.DeepEqual(evicted, []string{"a:capacity"}) {
+				t.Errorf("Expected a to be evicted by capacity, got %v", evicted)
+			}
+			if n := len(keyed.keys); n != 2 {
+				t.Errorf("Expected 2 keys, got %d", n)
+			}
+		})
+	}
+}
+
+func TestKeyedTTL(t *testing.T) {
+	evicted := make(chan string, 2)
+
+	keyed := NewKeyed[string](10*time.Millisecond,
+		WithTTL[string](50*time.Millisecond),
+		WithEvictHook(func(key string, reason EvictReason) {
+			evicted <- key + ":" + reason.String()
+		}),
+	)
+	defer keyed.Stop()
+
+	keyed.Do("a", func() {})
+	time.Sleep(30 * time.Millisecond)
+	keyed.Do("b", func() {})
+
+	for _, expected := range []string{"a:ttl", "b:ttl"} {
+		select {
+		case key := <-evicted:
+			if key != expected {
+				t.Errorf("Expected %s, got %s", expected, key)
+			}
+		case <-time.After(200 * time.Millisecond):
+			t.Errorf("Expected %s to be evicted", expected)
+		}
+	}
+
+	if n := keyed.Len(); n != 0 {
+		t.Errorf("Expected no keys, got %d", n)
+	}
+}
+
+func TestKeyedStop(t *testing.T) {
+	var called bool
+	keyed := NewKeyed[int](10 * time.Millisecond)
+
+	keyed.Do(1, func() { called = true })
+	keyed.Stop()
+	keyed.Do(2, func() { called = true })
+
+	time.Sleep(30 * time.Millisecond)
+	if called {
+		t.Error("Expected no calls after Stop")
+	}
+}
@@ -0,0 +1,183 @@
+package debounce
+
+import (
+	"sync"
+	"time"
+)
+
+// Semaphore caps the number of simultaneous executions of debounced functions.
+// Limiter of this package and of github.com/floatdrop/debounce/v2 both implement it,
+// and WithLimiter of both packages accepts either, so v1 and v2 debouncers can share one cap.
+type Semaphore interface {
+	// Acquire calls start, once a slot is free: right away or after another execution
+	// releases its slot. Executions of one owner are started in order.
+	Acquire(owner any, start func())
+	// Release frees the slot of a started execution.
+	Release()
+}
+
+// Limiter caps the number of simultaneous executions across all debouncers sharing it
+// with WithLimiter. Executions over the cap wait in per-debouncer queues, which are
+// served round-robin, so one noisy debouncer cannot starve the others.
+type Limiter struct {
+	mu       sync.Mutex
+	capacity int
+	running  int
+	waiting  int
+	queues   map[any]*limiterQueue // Queues with waiting executions by owner
+	active   []*limiterQueue       // Queues with waiting executions, in round-robin order
+
+	executions uint64
+	waitTotal  time.Duration
+	waitMax    time.Duration
+}
+
+// LimiterStats is a snapshot of Limiter metrics.
+type LimiterStats struct {
+	Running    int           // Number of executions in progress
+	Waiting    int           // Number of executions waiting for a free slot
+	Executions uint64        // Total number of started executions
+	WaitTotal  time.Duration // Total time executions spent waiting for a free slot
+	WaitMax    time.Duration // Longest time an execution waited for a free slot
+}
+
+// NewLimiter creates a Limiter, that allows at most n simultaneous executions.
+func NewLimiter(n int) *Limiter {
+	return &Limiter{capacity: n}
+}
+
+// WithLimiter makes the debouncer wait for a free slot in s, usually a *Limiter, before each execution.
+// It has effect only when the debouncer is created.
+func WithLimiter(s Semaphore) Option {
+	return func(d *debouncer) {
+		if !d.created {
+			d.limiter = s
+		}
+	}
+}
+
+// Stats returns a snapshot of Limiter metrics.
+func (l *Limiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return LimiterStats{
+		Running:    l.running,
+		Waiting:    l.waiting,
+		Executions: l.executions,
+		WaitTotal:  l.waitTotal,
+		WaitMax:    l.waitMax,
+	}
+}
+
+// limiterQueue holds executions of a single owner waiting for Limiter.
+type limiterQueue struct {
+	owner    any
+	jobs     []limiterJob // Executions waiting for a slot
+	ready    []func()     // Executions, that got a slot, in order of starting
+	starting bool         // Whether a goroutine starts ready executions
+}
+
+type limiterJob struct {
+	start  func()
+	queued time.Time
+}
+
+// Acquire calls start right away, if there is a free slot, or queues it for owner.
+func (l *Limiter) Acquire(owner any, start func()) {
+	l.mu.Lock()
+	q := l.queues[owner]
+	if l.running < l.capacity && len(l.active) == 0 {
+		l.running++
+		l.observe(0)
+		if q == nil {
+			l.mu.Unlock()
+			start()
+			return
+		}
+
+		// Earlier executions of owner are still being started, this one starts after them
+		q.ready = append(q.ready, start)
+		l.mu.Unlock()
+		return
+	}
+
+	if q == nil {
+		if l.queues == nil {
+			l.queues = make(map[any]*limiterQueue)
+		}
+		q = &limiterQueue{owner: owner}
+		l.queues[owner] = q
+	}
+	if len(q.jobs) == 0 {
+		l.active = append(l.active, q)
+	}
+	q.jobs = append(q.jobs, limiterJob{start: start, queued: time.Now()})
+	l.waiting++
+	l.mu.Unlock()
+}
+
+// Release frees the slot and starts the next waiting execution, if any.
+func (l *Limiter) Release() {
+	l.mu.Lock()
+	if len(l.active) == 0 {
+		l.running--
+		l.mu.Unlock()
+		return
+	}
+
+	// Slot passes to the first queue in round-robin order
+	q := l.active[0]
+	l.active[0] = nil
+	l.active = l.active[1:]
+
+	job := q.jobs[0]
+	q.jobs[0] = limiterJob{}
+	q.jobs = q.jobs[1:]
+	if len(q.jobs) > 0 {
+		l.active = append(l.active, q)
+	}
+
+	l.waiting--
+	l.observe(time.Since(job.queued))
+
+	q.ready = append(q.ready, job.start)
+	if q.starting {
+		l.mu.Unlock()
+		return
+	}
+	q.starting = true
+	l.mu.Unlock()
+
+	// Started on another goroutine, so executions releasing slots inline (e.g. with Inline executor)
+	// do not start all waiting executions on the same stack
+	go l.drain(q)
+}
+
+// drain starts ready executions of q one by one, in order.
+func (l *Limiter) drain(q *limiterQueue) {
+	l.mu.Lock()
+	for len(q.ready) > 0 {
+		start := q.ready[0]
+		q.ready[0] = nil
+		q.ready = q.ready[1:]
+
+		l.mu.Unlock()
+		start()
+		l.mu.Lock()
+	}
+	q.starting = false
+	if len(q.jobs) == 0 {
+		delete(l.queues, q.owner)
+	}
+	l.mu.Unlock()
+}
+
+// observe records started execution. Must be called with l.mu held.
+func (l *Limiter) observe(wait time.Duration) {
+	l.executions++
+	l.waitTotal += wait
+	if wait > l.waitMax {
+		l.waitMax = wait
+	}
+}
@@ -0,0 +1,159 @@
+package debounce
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	limiter := NewLimiter(2)
+
+	var wg sync.WaitGroup
+	var running, maxRunning int32
+
+	fn := func() {
+		defer wg.Done()
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	}
+
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		New(time.Millisecond, WithLimiter(limiter))(fn)
+	}
+	wg.Wait()
+
+	if maxRunning != 2 {
+		t.Errorf("Expected at most 2 concurrent executions, got %d", maxRunning)
+	}
+
+	stats := limiter.Stats()
+	if stats.Executions != 10 || stats.Waiting != 0 || stats.WaitMax == 0 || stats.WaitTotal < stats.WaitMax {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestLimiterFairness(t *testing.T) {
+	limiter := NewLimiter(1)
+	block := make(chan struct{})
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}
+	}
+
+	// Owners stand for debouncers sharing the limiter
+	noisy, quiet := new(int), new(int)
+	submit := func(owner any, fn func()) {
+		limiter.Acquire(owner, func() {
+			go func() {
+				defer limiter.Release()
+				fn()
+			}()
+		})
+	}
+
+	submit(noisy, func() { <-block })
+	for i := 0; i < 3; i++ {
+		submit(noisy, record("noisy"))
+	}
+	submit(quiet, record("quiet"))
+
+	if stats := limiter.Stats(); stats.Running != 1 || stats.Waiting != 4 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+
+	close(block)
+	for limiter.Stats().Executions != 5 || limiter.Stats().Running != 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// Quiet debouncer does not wait for all noisy executions
+	if len(order) != 4 || order[1] != "quiet" {
+		t.Errorf("Expected quiet execution to be second, got %v", order)
+	}
+}
+
+func TestLimiterInlineDepth(t *testing.T) {
+	limiter := NewLimiter(1)
+	block := make(chan struct{})
+
+	var wg sync.WaitGroup
+	var depth, maxDepth int32
+	fn := func() {
+		defer wg.Done()
+		if n := int32(runtime.Callers(0, make([]uintptr, 1024))); n > atomic.LoadInt32(&maxDepth) {
+			atomic.StoreInt32(&maxDepth, n)
+		}
+	}
+
+	wg.Add(101)
+	New(time.Millisecond, WithLimiter(limiter), WithExecutor(Inline))(func() {
+		defer wg.Done()
+		depth = int32(runtime.Callers(0, make([]uintptr, 1024)))
+		<-block
+	})
+	for limiter.Stats().Running != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	for i := 0; i < 100; i++ {
+		New(time.Millisecond, WithLimiter(limiter), WithExecutor(Inline), WithMaxCalls(1))(fn)
+	}
+	close(block)
+	wg.Wait()
+
+	// Waiting executions are not started on the stack of the released one
+	if maxDepth > depth+10 {
+		t.Errorf("Expected stack depth to stay bounded, got %d after %d", maxDepth, depth)
+	}
+}
+
+func TestLimiterOwnerOrder(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		limiter := NewLimiter(2)
+		limiter.Acquire(new(int), func() {})
+		limiter.Acquire(new(int), func() {})
+
+		var mu sync.Mutex
+		var order []int
+		var wg sync.WaitGroup
+		owner := new(int)
+		for n := 1; n <= 2; n++ {
+			n := n
+			wg.Add(1)
+			limiter.Acquire(owner, func() {
+				defer wg.Done()
+				mu.Lock()
+				order = append(order, n)
+				mu.Unlock()
+			})
+		}
+
+		// Both slots are released at once, executions of owner still start in order
+		go limiter.Release()
+		go limiter.Release()
+		wg.Wait()
+
+		if order[0] != 1 || order[1] != 2 {
+			t.Fatalf("Expected executions of one owner to start in order, got %v", order)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package debounce
+
+import "time"
+
+// Reason tells why a debounced function is due.
+type Reason int
+
+const (
+	ReasonNone     Reason = iota // Nothing is due
+	ReasonDelay                  // Period of inactivity after the last call has elapsed
+	ReasonMaxCalls               // Maximum number of calls was reached
+	ReasonMaxWait                // Maximum wait time was reached
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonDelay:
+		return "delay"
+	case ReasonMaxCalls:
+		return "max calls"
+	case ReasonMaxWait:
+		return "max wait"
+	default:
+		return "none"
+	}
+}
+
+// Poller is a debouncer driven by caller-supplied time, without timers or goroutines.
+// It reproduces the after, MaxCalls and MaxWait semantics of New for frame-locked loops
+// and deterministic replays: report calls with Call and poll with Tick on every frame.
+//
+// If a limit is reached by a call, the window is due on the next Tick and calls made
+// before that Tick are coalesced into it.
+//
+// Poller is not safe for concurrent use.
+type Poller struct {
+	after    time.Duration
+	maxCalls int
+	maxWait  time.Duration
+
+	calls     int
+	startWait time.Time
+	lastCall  time.Time
+	reason    Reason // Set when a limit is reached by a call
+}
+
+// NewPoller creates a Poller. Only WithDelay, WithMaxCalls and WithMaxWait options apply.
+func NewPoller(after time.Duration, options ...Option) *Poller {
+	d := debouncer{
+		after:    after,
+		maxCalls: NoLimitCalls,
+		maxWait:  NoLimitWait,
+	}
+	for _, opt := range options {
+		opt(&d)
+	}
+
+	return &Poller{
+		after:    d.after,
+		maxCalls: d.maxCalls,
+		maxWait:  d.maxWait,
+	}
+}
+
+// Call records a call made at now.
+func (p *Poller) Call(now time.Time) {
+	if p.calls == 0 {
+		p.startWait = now
+	}
+	p.calls++
+	p.lastCall = now
+
+	if p.reason != ReasonNone {
+		return
+	}
+
+	switch {
+	case p.maxCalls != NoLimitCalls && p.calls >= p.maxCalls:
+		p.reason = ReasonMaxCalls
+	case p.maxWait != NoLimitWait && now.Sub(p.startWait) >= p.maxWait:
+		p.reason = ReasonMaxWait
+	}
+}
+
+// Tick reports whether the debounced function should be executed at now and why.
+// When it returns true, the window is reset.
+func (p *Poller) Tick(now time.Time) (fire bool, reason Reason) {
+	if p.calls == 0 {
+		return false, ReasonNone
+	}
+
+	reason = p.reason
+	if reason == ReasonNone {
+		if now.Sub(p.lastCall) < p.after {
+			return false, ReasonNone
+		}
+		reason = ReasonDelay
+	}
+
+	p.calls = 0
+	p.reason = ReasonNone
+	return true, reason
+}
+
+// Pending reports whether there are calls, that were not fired yet.
+func (p *Poller) Pending() bool {
+	return p.calls > 0
+}
@@ -0,0 +1,81 @@
+package debounce
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPollerDelay(t *testing.T) {
+	p := NewPoller(100 * time.Millisecond)
+	start := time.Now()
+
+	if fire, reason := p.Tick(start); fire || reason != ReasonNone {
+		t.Errorf("Expected no fire without calls, got %v, %v", fire, reason)
+	}
+
+	p.Call(start)
+	p.Call(start.Add(50 * time.Millisecond))
+
+	if fire, _ := p.Tick(start.Add(100 * time.Millisecond)); fire {
+		t.Error("Expected no fire before quiet period after last call")
+	}
+	if !p.Pending() {
+		t.Error("Expected pending calls")
+	}
+
+	if fire, reason := p.Tick(start.Add(150 * time.Millisecond)); !fire || reason != ReasonDelay {
+		t.Errorf("Expected fire by delay, got %v, %v", fire, reason)
+	}
+
+	if fire, _ := p.Tick(start.Add(time.Second)); fire || p.Pending() {
+		t.Error("Expected window to be reset after fire")
+	}
+}
+
+func TestPollerMaxCalls(t *testing.T) {
+	p := NewPoller(time.Second, WithMaxCalls(3))
+	start := time.Now()
+
+	p.Call(start)
+	p.Call(start)
+	if fire, _ := p.Tick(start); fire {
+		t.Error("Expected no fire before MaxCalls")
+	}
+
+	p.Call(start)
+	p.Call(start) // Coalesced into due window
+	if fire, reason := p.Tick(start); !fire || reason != ReasonMaxCalls {
+		t.Errorf("Expected fire by MaxCalls, got %v, %v", fire, reason)
+	}
+}
+
+func TestPollerMaxWait(t *testing.T) {
+	p := NewPoller(100*time.Millisecond, WithMaxWait(250*time.Millisecond))
+	start := time.Now()
+
+	for i := 0; i <= 5; i++ {
+		now := start.Add(time.Duration(i) * 50 * time.Millisecond)
+		p.Call(now)
+		if fire, reason := p.Tick(now); fire {
+			if i != 5 || reason != ReasonMaxWait {
+				t.Errorf("Expected fire by MaxWait on 6th call, got %v on call %d", reason, i+1)
+			}
+			return
+		}
+	}
+	t.Error("Expected fire by MaxWait")
+}
+
+func TestReasonString(t *testing.T) {
+	reasons := map[Reason]string{
+		ReasonNone:     "none",
+		ReasonDelay:    "delay",
+		ReasonMaxCalls: "max calls",
+		ReasonMaxWait:  "max wait",
+	}
+	for r, s := range reasons {
+		if r.String() != s {
+			t.Errorf("Expected %q, got %q", s, r.String())
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package debounce
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Registry keeps track of named debouncers and serves a debug page listing them,
+// similar to the net/http/pprof pages. Debouncers are registered with WithName.
+//
+//	http.Handle("/debug/debounce", debounce.DefaultRegistry)
+type Registry struct {
+	mu         sync.Mutex
+	debouncers map[*debouncer]struct{}
+}
+
+// DefaultRegistry is the Registry used by WithName, unless WithRegistry is specified.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		debouncers: make(map[*debouncer]struct{}),
+	}
+}
+
+// WithName registers the debouncer under name in DefaultRegistry, or the registry
+// set with WithRegistry. Names are not required to be unique.
+//
+// Debouncers are unregistered, when they are stopped: NewControl by Control.Stop, NewActivity
+// by Activity.Stop, and keys of Keyed and Sharded, when they are evicted or stopped. Functions
+// returned by New, NewFunc, NewFunc1, NewFunc2 and NewFuncMerge can not be stopped, so they
+// stay registered until Registry.Unregister is called with their name.
+//
+// It has effect only when the debouncer is created.
+func WithName(name string) Option {
+	return func(d *debouncer) {
+		if !d.created {
+			d.name = name
+		}
+	}
+}
+
+// WithRegistry sets the Registry used by WithName.
+// It has effect only when the debouncer is created.
+func WithRegistry(r *Registry) Option {
+	return func(d *debouncer) {
+		if !d.created {
+			d.registry = r
+		}
+	}
+}
+
+// Unregister removes all debouncers registered under name and returns their number.
+// Unregistered debouncers keep working.
+func (r *Registry) Unregister(name string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for d := range r.debouncers {
+		if d.name == name {
+			delete(r.debouncers, d)
+			n++
+		}
+	}
+	return n
+}
+
+// Entry describes a registered debouncer.
+type Entry struct {
+	Name     string        `json:"name"`
+	Delay    time.Duration `json:"delay"`
+	MaxCalls int           `json:"max_calls,omitempty"` // Zero if there is no limit
+	MaxWait  time.Duration `json:"max_wait,omitempty"`  // Zero if there is no limit
+	State    State         `json:"state"`
+}
+
+// Entries returns descriptions of all registered debouncers, sorted by name.
+func (r *Registry) Entries() []Entry {
+	r.mu.Lock()
+	entries := make([]Entry, 0, len(r.debouncers))
+	for d := range r.debouncers {
+		entries = append(entries, d.entry())
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// ServeHTTP renders registered debouncers as plain text, or as JSON if
+// the request has format=json query parameter.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	entries := r.Entries()
+
+	if req.FormValue("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(entries)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "debounce: %d debouncers\n\n", len(entries))
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tDELAY\tMAX CALLS\tMAX WAIT\tPENDING\tPAUSED\tCALLS\tFIRST\tFIRE AT\tFIRES")
+	for _, e := range entries {
+		maxCalls, maxWait := "-", "-"
+		if e.MaxCalls != 0 {
+			maxCalls = strconv.Itoa(e.MaxCalls)
+		}
+		if e.MaxWait != 0 {
+			maxWait = e.MaxWait.String()
+		}
+
+		fmt.Fprintf(tw, "%s\t%v\t%s\t%s\t%t\t%t\t%d\t%s\t%s\t%d\n",
+			e.Name, e.Delay, maxCalls, maxWait,
+			e.State.Pending, e.State.Paused, e.State.Calls,
+			formatTime(e.State.First), formatTime(e.State.FireAt), e.State.Fires)
+	}
+	_ = tw.Flush()
+}
+
+// register adds d to its Registry, if it is named.
+func (d *debouncer) register() {
+	if d.name == "" {
+		return
+	}
+	if d.registry == nil {
+		d.registry = DefaultRegistry
+	}
+	d.registry.register(d)
+}
+
+func (r *Registry) register(d *debouncer) {
+	r.mu.Lock()
+	r.debouncers[d] = struct{}{}
+	r.mu.Unlock()
+}
+
+func (r *Registry) unregister(d *debouncer) {
+	r.mu.Lock()
+	delete(r.debouncers, d)
+	r.mu.Unlock()
+}
+
+func (d *debouncer) entry() Entry {
+	e := Entry{
+		State: d.state(),
+	}
+
+	d.mu.Lock()
+	e.Name = d.name
+	e.Delay = d.delay()
+	if d.maxCalls != NoLimitCalls {
+		e.MaxCalls = d.maxCalls
+	}
+	if d.maxWait != NoLimitWait {
+		e.MaxWait = d.maxWait
+	}
+	d.mu.Unlock()
+
+	return e
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339Nano)
+}
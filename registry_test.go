@@ -0,0 +1,104 @@
+package debounce
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+
+	b := NewControl(time.Second, WithName("b"), WithRegistry(registry), WithMaxCalls(10), WithMaxWait(time.Minute))
+	a := NewControl(time.Second, WithName("a"), WithRegistry(registry))
+	_ = New(time.Second, WithRegistry(registry)) // Unnamed debouncers are not registered
+
+	b.Do(func() {})
+
+	entries := registry.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %+v", entries)
+	}
+	if entries[0].Name != "a" || entries[0].MaxCalls != 0 || entries[0].MaxWait != 0 || entries[0].State.Pending {
+		t.Errorf("Unexpected entry for a: %+v", entries[0])
+	}
+	if entries[1].Name != "b" || entries[1].Delay != time.Second || entries[1].MaxCalls != 10 || entries[1].MaxWait != time.Minute || !entries[1].State.Pending {
+		t.Errorf("Unexpected entry for b: %+v", entries[1])
+	}
+
+	a.Stop()
+	b.Stop()
+
+	if entries := registry.Entries(); len(entries) != 0 {
+		t.Errorf("Expected no entries after Stop, got %+v", entries)
+	}
+}
+
+func TestRegistryServeHTTP(t *testing.T) {
+	registry := NewRegistry()
+	control := NewControl(time.Second, WithName("cache-rebuild"), WithRegistry(registry), WithMaxCalls(5))
+	defer control.Stop()
+
+	control.Do(func() {})
+
+	rec := httptest.NewRecorder()
+	registry.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/debounce", nil))
+
+	body := rec.Body.String()
+	if !strings.Contains(body, "debounce: 1 debouncers") || !strings.Contains(body, "cache-rebuild") {
+		t.Errorf("Unexpected text page:\n%s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	registry.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/debounce?format=json", nil))
+
+	var entries []Entry
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+		t.Fatalf("Failed to decode JSON page: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "cache-rebuild" || entries[0].MaxCalls != 5 || entries[0].State.Calls != 1 {
+		t.Errorf("Unexpected JSON page: %+v", entries)
+	}
+}
+
+func TestRegistryUnregister(t *testing.T) {
+	registry := NewRegistry()
+
+	// Functions returned by New can not be stopped, so they are unregistered by name
+	New(time.Second, WithName("func"), WithRegistry(registry))(func() {})
+	NewFunc1(func(int) {}, time.Second, WithName("func"), WithRegistry(registry))(1)
+
+	activity := NewActivity(time.Second, nil, nil, WithName("activity"), WithRegistry(registry))
+	keyed := NewKeyed(time.Second, WithKeyOptions[string](WithName("key"), WithRegistry(registry)))
+	keyed.Do("a", func() {})
+	keyed.Do("b", func() {})
+
+	if n := len(registry.Entries()); n != 5 {
+		t.Errorf("Expected 5 debouncers to be registered, got %d", n)
+	}
+
+	activity.Stop()
+	keyed.Stop()
+	if n := registry.Unregister("func"); n != 2 {
+		t.Errorf("Expected 2 debouncers to be unregistered by name, got %d", n)
+	}
+
+	if n := len(registry.Entries()); n != 0 {
+		t.Errorf("Expected all debouncers to be unregistered, got %d", n)
+	}
+}
+
+func TestRegistrySetOptions(t *testing.T) {
+	r1, r2 := NewRegistry(), NewRegistry()
+	control := NewControl(time.Second, WithName("a"), WithRegistry(r1))
+
+	// Registration is fixed at creation
+	control.SetOptions(WithName("b"), WithRegistry(r2))
+	control.Stop()
+
+	if n := len(r1.Entries()) + len(r2.Entries()); n != 0 {
+		t.Errorf("Expected debouncer to be unregistered, got %d entries", n)
+	}
+}
@@ -0,0 +1,143 @@
+package debounce
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSchedulerTimer(t *testing.T) {
+	s := NewScheduler(time.Millisecond)
+	defer s.Stop()
+
+	fired := make(chan time.Time, 1)
+	timer := s.afterFunc(func() { fired <- time.Now() })
+
+	if timer.Stop() {
+		t.Error("Expected Stop of new timer to return false")
+	}
+
+	start := time.Now()
+	if timer.Reset(20 * time.Millisecond) {
+		t.Error("Expected Reset of stopped timer to return false")
+	}
+	if !timer.Reset(30 * time.Millisecond) {
+		t.Error("Expected Reset of active timer to return true")
+	}
+
+	select {
+	case at := <-fired:
+		if at.Sub(start) < 30*time.Millisecond {
+			t.Errorf("Expected timer to fire after 30ms, fired after %v", at.Sub(start))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected timer to fire")
+	}
+
+	timer.Reset(10 * time.Millisecond)
+	if !timer.Stop() {
+		t.Error("Expected Stop of active timer to return true")
+	}
+
+	select {
+	case <-fired:
+		t.Error("Expected stopped timer to not fire")
+	case <-time.After(30 * time.Millisecond):
+	}
+}
+
+func TestSchedulerRounds(t *testing.T) {
+	s := NewScheduler(50 * time.Microsecond)
+	defer s.Stop()
+
+	// Delay is longer than full turn of the wheel
+	delay := 2 * wheelSize * 50 * time.Microsecond
+
+	fired := make(chan time.Time, 1)
+	timer := s.afterFunc(func() { fired <- time.Now() })
+
+	start := time.Now()
+	timer.Reset(delay)
+
+	select {
+	case at := <-fired:
+		if at.Sub(start) < delay {
+			t.Errorf("Expected timer to fire after %v, fired after %v", delay, at.Sub(start))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected timer to fire")
+	}
+}
+
+func TestWithScheduler(t *testing.T) {
+	s := NewScheduler(time.Millisecond)
+	defer s.Stop()
+
+	var called int
+	var mu sync.Mutex
+
+	fn := func() {
+		mu.Lock()
+		called++
+		mu.Unlock()
+	}
+
+	debounced := make([]func(func()), 100)
+	for i := range debounced {
+		debounced[i] = New(20*time.Millisecond, WithScheduler(s))
+	}
+
+	for j := 0; j < 3; j++ {
+		for i := range debounced {
+			debounced[i](fn)
+		}
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	mu.Lock()
+	if called != 0 {
+		t.Errorf("Expected 0 calls, got %d", called)
+	}
+	mu.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	if called != len(debounced) {
+		t.Errorf("Expected %d calls, got %d", len(debounced), called)
+	}
+	mu.Unlock()
+}
+
+func TestWithSchedulerInline(t *testing.T) {
+	s := NewScheduler(time.Millisecond)
+	defer s.Stop()
+
+	block := make(chan struct{})
+	defer close(block)
+	blocked := make(chan struct{})
+	New(5*time.Millisecond, WithScheduler(s), WithExecutor(Inline))(func() {
+		close(blocked)
+		<-block
+	})
+	<-blocked
+
+	// Blocked function does not stop other debouncers of the Scheduler
+	done := make(chan struct{})
+	New(5*time.Millisecond, WithScheduler(s))(func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(100 * time.Millisecond):
+		t.Error("Expected function to be called, while another one is blocked")
+	}
+}
+
+func TestSchedulerTick(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected NewScheduler to panic with non-positive tick")
+		}
+	}()
+	NewScheduler(0)
+}
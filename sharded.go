@@ -0,0 +1,104 @@
+package debounce
+
+import "time"
+
+// Sharded is a Keyed split into shards by key hash, so calls of keys in different shards
+// do not contend for the same lock. Use it instead of Keyed, when many goroutines call
+// many keys at once.
+type Sharded[K comparable] struct {
+	shards     []*Keyed[K]
+	hash       func(K) uint64
+	schedulers []*Scheduler
+}
+
+// ShardedOption is a functional option for configuring Sharded with keys of type K.
+type ShardedOption[K comparable] func(*shardedOptions[K])
+
+type shardedOptions[K comparable] struct {
+	tick    time.Duration
+	options []KeyedOption[K]
+}
+
+// WithShardScheduler gives every shard its own Scheduler with the given tick,
+// which drives timers of all keys in the shard.
+func WithShardScheduler[K comparable](tick time.Duration) ShardedOption[K] {
+	return func(o *shardedOptions[K]) {
+		o.tick = tick
+	}
+}
+
+// WithKeyedOptions sets options of every shard. WithCapacity is divided between shards,
+// so each shard tracks at most capacity/shards keys, rounded up.
+func WithKeyedOptions[K comparable](options ...KeyedOption[K]) ShardedOption[K] {
+	return func(o *shardedOptions[K]) {
+		o.options = options
+	}
+}
+
+// NewSharded creates a Sharded with the given number of shards, that debounces calls
+// of every key for after duration. hash maps keys to shards, for example
+// maphash.String with a fixed seed for string keys. Less than one shard means one shard.
+func NewSharded[K comparable](after time.Duration, shards int, hash func(K) uint64, options ...ShardedOption[K]) *Sharded[K] {
+	if shards < 1 {
+		shards = 1
+	}
+
+	var o shardedOptions[K]
+	for _, opt := range options {
+		opt(&o)
+	}
+
+	s := &Sharded[K]{
+		shards: make([]*Keyed[K], shards),
+		hash:   hash,
+	}
+
+	for i := range s.shards {
+		keyed := append([]KeyedOption[K]{}, o.options...)
+		keyed = append(keyed, func(ko *keyedOptions[K]) {
+			if ko.capacity > 0 {
+				ko.capacity = (ko.capacity + shards - 1) / shards
+			}
+		})
+
+		if o.tick > 0 {
+			scheduler := NewScheduler(o.tick)
+			s.schedulers = append(s.schedulers, scheduler)
+			keyed = append(keyed, func(ko *keyedOptions[K]) {
+				ko.options = append(ko.options[:len(ko.options):len(ko.options)], WithScheduler(scheduler))
+			})
+		}
+
+		s.shards[i] = NewKeyed[K](after, keyed...)
+	}
+
+	return s
+}
+
+// Do debounces fn for key.
+func (s *Sharded[K]) Do(key K, fn func()) {
+	s.shard(key).Do(key, fn)
+}
+
+// Len returns the number of tracked keys in all shards.
+func (s *Sharded[K]) Len() int {
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Stop drops pending calls of all keys and stops shard schedulers. Calls made after Stop are ignored.
+func (s *Sharded[K]) Stop() {
+	for _, shard := range s.shards {
+		shard.Stop()
+	}
+	for _, scheduler := range s.schedulers {
+		scheduler.Stop()
+	}
+}
+
+func (s *Sharded[K]) shard(key K) *Keyed[K] {
+	return s.shards[s.hash(key)%uint64(len(s.shards))]
+}
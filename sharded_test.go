@@ -0,0 +1,91 @@
+package debounce
+
+import (
+	"hash/maphash"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSharded(t *testing.T) {
+	var called int32
+
+	seed := maphash.MakeSeed()
+	sharded := NewSharded(20*time.Millisecond, 4, func(key string) uint64 {
+		return maphash.String(seed, key)
+	})
+	defer sharded.Stop()
+
+	var wg sync.WaitGroup
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		key := key
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				sharded.Do(key, func() { atomic.AddInt32(&called, 1) })
+			}
+		}()
+	}
+	wg.Wait()
+
+	time.Sleep(60 * time.Millisecond)
+
+	if n := atomic.LoadInt32(&called); n != 8 {
+		t.Errorf("Expected one call per key, got %d", n)
+	}
+	if n := sharded.Len(); n != 8 {
+		t.Errorf("Expected 8 keys, got %d", n)
+	}
+}
+
+func TestShardedOptions(t *testing.T) {
+	var evicted int32
+
+	sharded := NewSharded(time.Second, 2, func(key int) uint64 { return uint64(key) },
+		WithShardScheduler[int](time.Millisecond),
+		WithKeyedOptions(
+			WithCapacity[int](4),
+			WithEvictPolicy[int](EvictDrop),
+			WithEvictHook(func(int, EvictReason) { atomic.AddInt32(&evicted, 1) }),
+		),
+	)
+	defer sharded.Stop()
+
+	// Every shard tracks at most 2 keys
+	for key := 0; key < 10; key++ {
+		sharded.Do(key, func() {})
+	}
+
+	if n := sharded.Len(); n != 4 {
+		t.Errorf("Expected 4 keys, got %d", n)
+	}
+	if n := atomic.LoadInt32(&evicted); n != 6 {
+		t.Errorf("Expected 6 evictions, got %d", n)
+	}
+	for _, shard := range sharded.shards {
+		for _, e := range shard.keys {
+			if e.d.scheduler == nil {
+				t.Error("Expected key debouncers to use shard scheduler")
+			}
+		}
+	}
+}
+
+func TestShardedZeroShards(t *testing.T) {
+	for _, shards := range []int{0, -1} {
+		done := make(chan struct{}, 1)
+		sharded := NewSharded(time.Millisecond, shards, func(key int) uint64 { return uint64(key) },
+			WithKeyedOptions(WithCapacity[int](2)),
+		)
+
+		sharded.Do(1, func() { done <- struct{}{} })
+		select {
+		case <-done:
+		case <-time.After(100 * time.Millisecond):
+			t.Errorf("Expected function to be called with %d shards", shards)
+		}
+		sharded.Stop()
+	}
+}
@@ -0,0 +1,39 @@
+package debounce
+
+import "time"
+
+// State is a snapshot of a debouncer, returned by Control.State.
+type State struct {
+	Pending bool      `json:"pending"` // Whether a call is waiting to be executed
+	Paused  bool      `json:"paused"`  // Whether the debouncer is paused
+	Calls   int       `json:"calls"`   // Number of calls coalesced into the pending one
+	First   time.Time `json:"first"`   // Time of the first pending call, zero if nothing is pending
+	FireAt  time.Time `json:"fire_at"` // Time the pending call is scheduled to be executed, zero if not scheduled
+	Fires   uint64    `json:"fires"`   // Total number of executions so far
+}
+
+// State returns a snapshot of the debouncer. It is safe to call concurrently with debounced calls.
+func (c *Control) State() State {
+	return c.d.state()
+}
+
+func (d *debouncer) state() State {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	calls := d.calls + int(d.extra.Load())
+	s := State{
+		Pending: calls > 0,
+		Paused:  d.paused,
+		Calls:   calls,
+		Fires:   d.fires,
+	}
+	if s.Pending {
+		s.First = d.startWait
+	}
+	if !d.fireAt.IsZero() {
+		// Calls on the lock-free path could extend the quiet period
+		s.FireAt = d.due()
+	}
+	return s
+}
@@ -0,0 +1,74 @@
+package debounce
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestControlState(t *testing.T) {
+	control := NewControl(50*time.Millisecond, WithMaxCalls(3))
+
+	if s := control.State(); s != (State{}) {
+		t.Errorf("Expected zero state, got %+v", s)
+	}
+
+	start := time.Now()
+	control.Do(func() {})
+	control.Do(func() {})
+
+	s := control.State()
+	if !s.Pending || s.Calls != 2 || s.Fires != 0 {
+		t.Errorf("Expected 2 pending calls without fires, got %+v", s)
+	}
+	if s.First.Before(start) || s.FireAt.Before(s.First.Add(50*time.Millisecond)) {
+		t.Errorf("Expected First >= %v and FireAt >= First + 50ms, got %+v", start, s)
+	}
+
+	control.Do(func() {}) // MaxCalls reached
+
+	s = control.State()
+	if s.Pending || s.Calls != 0 || s.Fires != 1 || !s.First.IsZero() || !s.FireAt.IsZero() {
+		t.Errorf("Expected 1 fire without pending calls, got %+v", s)
+	}
+
+	control.Pause()
+	control.Do(func() {})
+
+	s = control.State()
+	if !s.Pending || !s.Paused || !s.FireAt.IsZero() {
+		t.Errorf("Expected paused pending call without schedule, got %+v", s)
+	}
+
+	control.Resume()
+	if s = control.State(); s.Fires != 2 {
+		t.Errorf("Expected 2 fires, got %+v", s)
+	}
+}
+
+func TestControlStateConcurrent(t *testing.T) {
+	control := NewControl(time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				control.Do(func() {})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = control.State()
+			}
+		}()
+	}
+	wg.Wait()
+
+	time.Sleep(10 * time.Millisecond)
+	if s := control.State(); s.Pending || s.Fires == 0 {
+		t.Errorf("Expected fired debouncer, got %+v", s)
+	}
+}
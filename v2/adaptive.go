@@ -0,0 +1,53 @@
+package debounce
+
+import "time"
+
+// adaptiveAlpha is the smoothing factor of the moving average of gaps between values.
+const adaptiveAlpha = 0.2
+
+// adaptive tracks an exponentially weighted moving average (EWMA) of gaps between
+// received values and derives the debounce delay from it.
+type adaptive struct {
+	factor   float64
+	min, max time.Duration
+
+	avg  float64   // Average gap in nanoseconds, zero until the first gap is observed
+	last time.Time // Time of the previous value in the pending window
+}
+
+// WithAdaptiveDelay replaces the fixed delay with one derived from the observed input rate:
+// factor times the moving average of gaps between values, clamped between min and max.
+// Only gaps between values of the same pending window are sampled, so idle time between
+// bursts does not inflate the delay. Until the first gap is observed, the WithDelay value
+// (clamped the same way) is used.
+func WithAdaptiveDelay(factor float64, min, max time.Duration) Option {
+	return func(options *options) {
+		options.adaptive = &adaptive{
+			factor: factor,
+			min:    min,
+			max:    max,
+		}
+	}
+}
+
+// observe records a value received at now. pending reports whether the value
+// continues an already pending window.
+func (a *adaptive) observe(now time.Time, pending bool) {
+	if pending {
+		gap := float64(now.Sub(a.last))
+		if a.avg == 0 {
+			a.avg = gap
+		} else {
+			a.avg += adaptiveAlpha * (gap - a.avg)
+		}
+	}
+	a.last = now
+}
+
+// delay returns the debounce delay to use, falling back to d if no gap was observed yet.
+func (a *adaptive) delay(d time.Duration) time.Duration {
+	if a.avg != 0 {
+		d = time.Duration(a.factor * a.avg)
+	}
+	return min(max(d, a.min), a.max)
+}
@@ -0,0 +1,29 @@
+package debounce
+
+import "time"
+
+// WithAlign rounds the time a value is emitted up to the next clock boundary: a multiple
+// of period since the Unix epoch, shifted by offset. For example, WithAlign(10*time.Second, 0)
+// emits on the next whole 10 seconds after the delay. Emissions forced by WithLimit are not aligned.
+func WithAlign(period, offset time.Duration) Option {
+	return func(options *options) {
+		options.alignPeriod = period
+		options.alignOffset = offset
+	}
+}
+
+// align rounds t up to the next boundary of period shifted by offset. Non-positive period disables alignment.
+func align(t time.Time, period, offset time.Duration) time.Time {
+	if period <= 0 {
+		return t
+	}
+
+	rem := (t.UnixNano() - int64(offset)) % int64(period)
+	if rem < 0 {
+		rem += int64(period)
+	}
+	if rem == 0 {
+		return t
+	}
+	return t.Add(period - time.Duration(rem))
+}
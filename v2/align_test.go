@@ -0,0 +1,41 @@
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+func TestMachine_Align(t *testing.T) {
+	m := debounce.NewMachine[int](debounce.WithDelay(10*time.Millisecond), debounce.WithAlign(100*time.Millisecond, 30*time.Millisecond))
+	defer m.Stop()
+
+	m.Push(1)
+
+	s := m.State()
+	if (s.FireAt.UnixNano()-int64(30*time.Millisecond))%int64(100*time.Millisecond) != 0 {
+		t.Errorf("expected fire time aligned to 100ms with 30ms offset, got %v", s.FireAt)
+	}
+	if d := s.FireAt.Sub(s.First); d < 10*time.Millisecond || d > 110*time.Millisecond {
+		t.Errorf("expected fire time within one period after the delay, got %v", d)
+	}
+}
+
+func TestDebounce_Align(t *testing.T) {
+	in := make(chan int)
+	out := debounce.Chan(in, debounce.WithDelay(10*time.Millisecond), debounce.WithAlign(50*time.Millisecond, 0))
+
+	in <- 1
+
+	select {
+	case <-out:
+		// Timers never fire early, so emission happens right after the boundary
+		if rem := time.Now().UnixNano() % int64(50*time.Millisecond); rem > int64(40*time.Millisecond) {
+			t.Errorf("expected emission right after 50ms boundary, got %v past it", time.Duration(rem))
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Error("expected value to be emitted")
+	}
+	close(in)
+}
@@ -0,0 +1,167 @@
+package debounce
+
+import (
+	"sync"
+	"time"
+)
+
+// Control is a handle to a running Chan, bound to it with WithControl.
+// It allows changing the debounce configuration without recreating the channel.
+// A Control must be bound to a single Chan.
+type Control struct {
+	mu      sync.Mutex
+	pending []Option      // Options waiting to be applied by the Chan goroutine
+	signal  chan struct{} // Notifies the Chan goroutine about pending options
+	state   State         // Last state published by the Chan goroutine
+	delay   time.Duration // Delay published by the Chan goroutine
+	limit   int           // Limit published by the Chan goroutine
+
+	closed    chan struct{} // Closed, when the Chan goroutine exits
+	closeOnce sync.Once
+}
+
+// WithControl binds c to the created Chan, so it can be reconfigured later.
+func WithControl(c *Control) Option {
+	return func(options *options) {
+		c.init()
+		options.control = c
+	}
+}
+
+func (c *Control) init() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.signal == nil {
+		c.signal = make(chan struct{}, 1)
+		c.closed = make(chan struct{})
+	}
+}
+
+// close marks the bound Chan as exited, so nothing waits for it to apply options.
+func (c *Control) close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+	})
+}
+
+// SetOptions applies options (WithDelay, WithLimit, ...) to the bound Chan asynchronously:
+// the Chan goroutine applies all options of a call together, in the order of SetOptions calls.
+//
+// A pending value keeps its reset count. If the new limit is already reached, the value
+// is emitted immediately. Otherwise its delay is restarted with the new configuration,
+// counted from the moment the options are applied.
+//
+// WithDelay, WithLimit, WithAdaptiveDelay, WithJitter, WithJitterFraction, WithRand, WithAlign,
+// WithGate and WithOnClose can be changed. Options read when Chan or Debouncer is created
+// (WithOutputPolicy, WithExecutor, WithLimiter, WithSlotInput, WithScheduler, WithName
+// and WithRegistry) are ignored.
+func (c *Control) SetOptions(opts ...Option) {
+	c.init()
+
+	c.mu.Lock()
+	c.pending = append(c.pending, opts...)
+	c.mu.Unlock()
+
+	select {
+	case c.signal <- struct{}{}:
+	default: // Chan goroutine is already notified
+	}
+}
+
+// State returns a snapshot of the bound Chan. It is safe to call concurrently with sending values.
+func (c *Control) State() State {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
+
+func (c *Control) publish(state State, o *options) {
+	c.mu.Lock()
+	c.state = state
+	c.delay = o.currentDelay()
+	c.limit = o.limit
+	c.mu.Unlock()
+}
+
+// wait applies opts like SetOptions and waits until the Chan goroutine has applied them
+// and published the new state, or has exited.
+func (c *Control) wait(opts ...Option) {
+	done := make(chan struct{})
+	c.SetOptions(append(opts, withAck(done))...)
+	select {
+	case <-done:
+	case <-c.closed:
+	}
+}
+
+// withAck makes the Chan goroutine close done, once the options are applied and the state is published.
+func withAck(done chan struct{}) Option {
+	return func(options *options) {
+		options.acks = append(options.acks, done)
+	}
+}
+
+// updates returns channel, that receives a value when new options are pending.
+func (c *Control) updates() <-chan struct{} {
+	if c == nil {
+		return nil
+	}
+	return c.signal
+}
+
+// apply applies pending options to o.
+func (c *Control) apply(o *options) {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+
+	created := *o
+	for _, opt := range pending {
+		opt(o)
+	}
+	o.keepCreated(&created)
+	o.control = c // Rebinding is not supported
+}
+
+// keepCreated restores options, that are read only when Chan or Debouncer is created.
+func (o *options) keepCreated(created *options) {
+	o.output, o.outputSize = created.output, created.outputSize
+	o.executor, o.limiter = created.executor, created.limiter
+	o.slotInput = created.slotInput
+	o.scheduler = created.scheduler
+	o.name, o.registry = created.name, created.registry
+}
+
+// Pause holds emission of values. New values are still received and the last one is retained,
+// but delay and limit triggers are suppressed until Resume.
+//
+// Pause returns once the bound Chan is paused, so values sent after it are held.
+// It must be called after the Chan is created.
+func (c *Control) Pause() {
+	c.init()
+	c.wait(withPaused(true))
+}
+
+// Resume ends a pause. If any values were received in the meantime, the last one is emitted immediately.
+// Like Pause, Resume returns once the bound Chan is resumed.
+func (c *Control) Resume() {
+	c.init()
+	c.wait(withPaused(false))
+}
+
+func withPaused(paused bool) Option {
+	return func(options *options) {
+		options.paused = paused
+	}
+}
+
+// WithGate pauses Chan when false is received from gate and resumes it when true is received.
+// While paused, values are still received and the last one is retained, but delay and limit
+// triggers are suppressed. On resume the retained value is emitted immediately.
+// Closing gate resumes Chan permanently.
+func WithGate(gate <-chan bool) Option {
+	return func(options *options) {
+		options.gate = gate
+	}
+}
@@ -0,0 +1,193 @@
+package debounce_test
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+func TestControl_SetDelay(t *testing.T) {
+	var control debounce.Control
+	in := make(chan int)
+	out := debounce.Chan(in, debounce.WithDelay(time.Second), debounce.WithControl(&control))
+
+	go func() {
+		in <- 1
+		control.SetOptions(debounce.WithDelay(50 * time.Millisecond))
+		time.Sleep(200 * time.Millisecond)
+		in <- 2
+		time.Sleep(200 * time.Millisecond)
+		close(in)
+	}()
+
+	expected := []int{1, 2}
+	result := collect(out, 500*time.Millisecond)
+	if !slices.Equal(expected, result) {
+		t.Errorf("expected result = %v, got %v", expected, result)
+	}
+}
+
+func TestControl_SetLimit(t *testing.T) {
+	var control debounce.Control
+	in := make(chan int)
+	out := debounce.Chan(in, debounce.WithDelay(time.Second), debounce.WithControl(&control))
+
+	go func() {
+		in <- 1
+		in <- 2
+		in <- 3
+		control.SetOptions(debounce.WithLimit(3)) // already reached by pending value
+		time.Sleep(100 * time.Millisecond)
+		close(in)
+	}()
+
+	expected := []int{3}
+	result := collect(out, 50*time.Millisecond)
+	if !slices.Equal(expected, result) {
+		t.Errorf("expected result = %v, got %v", expected, result)
+	}
+}
+
+func TestControl_ZeroDelay(t *testing.T) {
+	var control debounce.Control
+	in := make(chan int)
+	out := debounce.Chan(in, debounce.WithControl(&control))
+	if (<-chan int)(in) == out {
+		t.Errorf("expected Chan with control to not return input channel")
+	}
+	close(in)
+}
+
+func TestDebouncer_SetOptions(t *testing.T) {
+	var counter int32
+	debouncer := debounce.New(debounce.WithDelay(time.Second))
+	defer debouncer.Close()
+
+	debouncer.Do(func() { atomic.AddInt32(&counter, 1) })
+	debouncer.SetOptions(debounce.WithDelay(50 * time.Millisecond))
+
+	time.Sleep(200 * time.Millisecond)
+	if c := atomic.LoadInt32(&counter); c != 1 {
+		t.Errorf("expected counter = 1, got %d", c)
+	}
+}
+
+func TestControl_CreationOptions(t *testing.T) {
+	var control debounce.Control
+	r1, r2 := debounce.NewRegistry(), debounce.NewRegistry()
+	in := make(chan int)
+	out := debounce.Chan(in, debounce.WithDelay(time.Second), debounce.WithName("a"), debounce.WithRegistry(r1), debounce.WithControl(&control))
+
+	// Registration is fixed at creation, while close policy can be changed
+	control.SetOptions(debounce.WithRegistry(r2), debounce.WithOnClose(debounce.CloseDrop))
+	control.Pause()
+	control.Resume()
+	if n := len(r1.Entries()); n != 1 {
+		t.Errorf("expected Chan to stay registered, got %d entries", n)
+	}
+	if n := len(r2.Entries()); n != 0 {
+		t.Errorf("expected Chan not to move to another registry, got %d entries", n)
+	}
+
+	in <- 1
+	close(in)
+	if result := collect(out, 100*time.Millisecond); len(result) != 0 {
+		t.Errorf("expected pending value to be dropped on close, got %v", result)
+	}
+	if n := len(r1.Entries()); n != 0 {
+		t.Errorf("expected Chan to be unregistered, got %d entries", n)
+	}
+}
+
+func TestControl_PauseResume(t *testing.T) {
+	var control debounce.Control
+	in := make(chan int)
+	out := debounce.Chan(in, debounce.WithDelay(20*time.Millisecond), debounce.WithLimit(2), debounce.WithControl(&control))
+
+	// Values are sent only after the pause is confirmed
+	control.Pause()
+	if !control.State().Paused {
+		t.Fatal("expected Chan to be paused, when Pause returns")
+	}
+	in <- 1
+	in <- 2
+	in <- 3
+
+	if result := collect(out, 100*time.Millisecond); len(result) != 0 {
+		t.Errorf("expected no values while paused, got %v", result)
+	}
+
+	control.Resume()
+	expected := []int{3}
+	result := collect(out, 100*time.Millisecond)
+	if !slices.Equal(expected, result) {
+		t.Errorf("expected result = %v, got %v", expected, result)
+	}
+	close(in)
+}
+
+func TestGate(t *testing.T) {
+	in := make(chan int)
+	gate := make(chan bool)
+	out := debounce.Chan(in, debounce.WithDelay(20*time.Millisecond), debounce.WithGate(gate))
+
+	go func() {
+		gate <- false
+		in <- 1
+		in <- 2
+		time.Sleep(100 * time.Millisecond)
+		gate <- true
+		in <- 3
+		gate <- false
+		in <- 4
+		close(gate) // resumes permanently
+		in <- 5
+		time.Sleep(100 * time.Millisecond)
+		close(in)
+	}()
+
+	expected := []int{2, 4, 5}
+	result := collect(out, 500*time.Millisecond)
+	if !slices.Equal(expected, result) {
+		t.Errorf("expected result = %v, got %v", expected, result)
+	}
+}
+
+func TestDebouncer_PauseResume(t *testing.T) {
+	var counter int32
+	debouncer := debounce.New(debounce.WithDelay(20 * time.Millisecond))
+	defer debouncer.Close()
+
+	debouncer.Pause()
+	debouncer.Do(func() { atomic.AddInt32(&counter, 1) })
+	debouncer.Do(func() { atomic.AddInt32(&counter, 10) })
+
+	time.Sleep(100 * time.Millisecond)
+	if c := atomic.LoadInt32(&counter); c != 0 {
+		t.Errorf("expected counter = 0, got %d", c)
+	}
+
+	debouncer.Resume()
+	time.Sleep(50 * time.Millisecond)
+	if c := atomic.LoadInt32(&counter); c != 10 {
+		t.Errorf("expected counter = 10, got %d", c)
+	}
+}
+
+func TestDebouncer_PauseLimit(t *testing.T) {
+	for range 200 {
+		var executed atomic.Bool
+		debouncer := debounce.New(debounce.WithDelay(time.Hour), debounce.WithLimit(1))
+
+		// Limit is suppressed for functions submitted after Pause returns
+		debouncer.Pause()
+		debouncer.Do(func() { executed.Store(true) })
+		if s := debouncer.State(); s.Fires != 0 || executed.Load() {
+			t.Fatalf("expected no execution while paused, got %+v", s)
+		}
+		debouncer.Close()
+	}
+}
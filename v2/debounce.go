@@ -3,13 +3,39 @@
 package debounce
 
 import (
+	"math/rand/v2"
 	"time"
 )
 
 // options encapsulates the debounce configuration: delay and limit.
 type options struct {
-	limit int
-	delay time.Duration
+	limit    int
+	delay    time.Duration
+	adaptive *adaptive
+	control  *Control
+	gate     <-chan bool
+	paused   bool
+	name     string
+	registry *Registry
+
+	scheduler *Scheduler
+	executor  Executor
+	limiter   Semaphore
+	slotInput bool
+
+	output     OutputPolicy
+	outputSize int
+	onClose    ClosePolicy
+
+	jitter         time.Duration
+	jitterFraction float64
+	rand           *rand.Rand
+
+	alignPeriod time.Duration
+	alignOffset time.Duration
+
+	withdraw bool            // Requested by Ticket.Cancel, applied by the Chan goroutine
+	acks     []chan struct{} // Closed by the Chan goroutine, once options are applied
 }
 
 // Option is a functional option for configuring the debouncer.
@@ -39,7 +65,27 @@ func WithDelay(d time.Duration) Option {
 // If both are set, a value will be emitted after either the `delay` passes without new input,
 // or after the delay has been reset `limit` times.
 //
-// If delay is 0, the function returns the input channel unmodified.
+// WithAdaptiveDelay makes the delay follow the observed input rate instead of staying fixed.
+//
+// WithControl binds a Control, which can change the configuration while Chan is running.
+//
+// WithGate pauses and resumes emission from an external channel.
+//
+// WithJitter and WithJitterFraction randomize the delay of every value window.
+//
+// WithAlign rounds emission time up to clock boundaries.
+//
+// WithScheduler drives the delay with a shared Scheduler instead of a runtime timer.
+//
+// WithName registers Chan in a Registry until the input channel is closed.
+//
+// WithOutputPolicy decides what happens to settled values, when the consumer is slow.
+//
+// WithOnClose decides what happens to the pending value, when the input channel is closed.
+//
+// If delay is 0 and neither adaptive delay, control, gate, name nor output policy is configured,
+// the function returns the input channel unmodified. Otherwise, while delay is 0, every value
+// is emitted as it is received, like from the input channel.
 func Chan[T any](in <-chan T, opts ...Option) <-chan T {
 	var options options
 	for _, opt := range opts {
@@ -47,73 +93,212 @@ func Chan[T any](in <-chan T, opts ...Option) <-chan T {
 	}
 
 	// Optimization: no debouncing if delay is zero
-	if options.delay == 0 {
+	if options.delay == 0 && options.jitter == 0 && options.alignPeriod == 0 && options.adaptive == nil && options.control == nil && options.gate == nil && options.name == "" &&
+		options.output == OutputBlock && options.outputSize <= 1 {
 		return in
 	}
 
-	out := make(chan T, 1)
+	return start(in, nil, options, hooks[T]{})
+}
+
+// hooks are callbacks of Debouncer, that the Chan goroutine calls with its values.
+type hooks[T any] struct {
+	deadline func(T) time.Time                                    // Time a value received from in must settle by
+	withdraw func(first, last T, pending bool) (n int, drop bool) // Update of the pending window after Ticket.Cancel
+}
+
+// start runs the Chan goroutine, that debounces values received from in or sent to input.
+func start[T any](in <-chan T, input *Input[T], options options, h hooks[T]) <-chan T {
+	// Named Chan needs Control to be inspected by Registry
+	if options.name != "" && options.control == nil {
+		options.control = &Control{}
+		options.control.init()
+	}
+
+	if options.control != nil {
+		options.control.publish(State{}, &options) // Initial configuration is visible before the first value
+	}
+
+	if options.name != "" {
+		if options.registry == nil {
+			options.registry = DefaultRegistry
+		}
+		options.registry.register(options.name, options.control)
+	}
+
+	out := make(chan T, max(options.outputSize, 1))
 	go func() {
 		defer close(out)
+		if options.control != nil {
+			defer options.control.close()
+		}
+		if options.name != "" {
+			defer options.registry.unregister(options.control)
+		}
 
-		var (
-			delayTimer *time.Timer // Timer to manage delay
-			lastValue  T           // Last received value
-			hasValue   bool        // Whether a value is currently pending emission
-			count      int         // Number of delay resets since last emission
-		)
-
-		emitLastValue := func() {
-			if hasValue {
-				out <- lastValue
-				hasValue = false
-				count = 0
-				if delayTimer != nil {
-					delayTimer.Stop()
-				}
+		m := newMachine[T](options)
+		defer m.Stop()
+
+		emit := func(v T, ok bool) {
+			if ok {
+				deliver(out, v, options.output)
+			}
+		}
+
+		// Publishes state snapshot to the bound Control
+		publish := func() {
+			if m.options.control != nil {
+				m.options.control.publish(m.State(), &m.options)
+			}
+		}
+
+		var first T              // First value of the pending window
+		var acks []chan struct{} // Updates, that wait for the state to be published
+
+		// Handles the pending value, when input is closed
+		closing := false
+		finish := func() {
+			closing = true
+			in, input = nil, nil
+			switch m.options.onClose {
+			case CloseDrop:
+				m.drop()
+			case CloseEmitAfterDelay:
+				// Pending value settles as usual
+			default:
+				emit(m.Flush())
 			}
 		}
 
 		for {
+			wake, done := input.signals()
 			select {
+			case <-wake:
+				if v, n, deadline, ok := input.take(); ok && m.push(v, n, deadline) {
+					emit(m.Take())
+				}
+			case <-done:
+				// Input closed — handle values sent before Close and any pending value.
+				if v, n, deadline, ok := input.take(); ok && m.push(v, n, deadline) {
+					emit(m.Take())
+				}
+				finish()
 			case v, ok := <-in:
 				if !ok {
-					// Input channel closed — emit any pending value.
-					emitLastValue()
-					return
+					// Input channel closed — handle any pending value.
+					finish()
+					break
 				}
 
-				lastValue = v
-				hasValue = true
-
-				// On every new input, increment the reset count.
-				count++
-
-				// Force emit if limit reached
-				if options.limit != 0 && count >= options.limit {
-					emitLastValue()
-					continue
+				var by time.Time
+				if h.deadline != nil {
+					by = h.deadline(v)
 				}
+				if !m.Pending() {
+					first = v
+				}
+				if m.push(v, 1, by) {
+					// Force emit if limit reached
+					emit(m.Take())
+				}
+			case <-m.C():
+				emit(m.Take())
+			case <-m.options.control.updates():
+				paused := m.options.paused
+				m.options.control.apply(&m.options)
+				h.apply(m, first)
+				acks, m.options.acks = m.options.acks, nil
+				if paused && !m.options.paused {
+					emit(m.Flush()) // Resumed — release pending value
+				} else if m.rearm(time.Now()) {
+					emit(m.Take())
+				}
+			case open, ok := <-m.options.gate:
+				if !ok {
+					m.options.gate = nil
+					open = true // Closed gate stays open
+				}
+				switch {
+				case m.options.paused && open:
+					m.options.paused = false
+					emit(m.Flush()) // Resumed — release pending value
+				case !m.options.paused && !open:
+					m.options.paused = true
+					m.rearm(time.Now())
+				}
+			}
+			publish()
+			for _, done := range acks {
+				close(done)
+			}
+			acks = nil
 
-				delayTimer = restartTimer(delayTimer, options.delay)
-			case <-timerChanOrNil(delayTimer):
-				emitLastValue()
+			if closing && !m.Pending() {
+				return
 			}
 		}
 	}()
 	return out
 }
 
-func timerChanOrNil(timer *time.Timer) <-chan time.Time {
-	if timer != nil {
-		return timer.C
+// limitReached reports whether count delay resets exceed the configured limit.
+func (o *options) limitReached(count int) bool {
+	return o.limit != 0 && count >= o.limit
+}
+
+// currentDelay returns the debounce delay for the pending value.
+func (o *options) currentDelay() time.Duration {
+	if o.adaptive != nil {
+		return o.adaptive.delay(o.delay)
+	}
+	return o.delay
+}
+
+// chanTimer is a runtime timer, or a Scheduler timer if Chan is configured WithScheduler.
+type chanTimer struct {
+	timer *time.Timer    // Runtime timer, created on first reset
+	wheel *wheelTimer    // Scheduler timer, signals to c
+	c     chan time.Time // Channel of Scheduler timer
+}
+
+func newChanTimer(s *Scheduler) *chanTimer {
+	t := &chanTimer{}
+	if s != nil {
+		t.c = make(chan time.Time, 1)
+		t.wheel = s.afterFunc(func() {
+			select {
+			case t.c <- time.Now():
+			default: // Chan goroutine is already signaled
+			}
+		})
+	}
+	return t
+}
+
+// C returns channel, that receives a value when timer fires, or nil if timer was never started.
+func (t *chanTimer) C() <-chan time.Time {
+	if t.timer != nil {
+		return t.timer.C
+	}
+	return t.c
+}
+
+func (t *chanTimer) reset(d time.Duration) {
+	switch {
+	case t.wheel != nil:
+		t.wheel.Reset(d)
+	case t.timer != nil:
+		t.timer.Reset(d)
+	default:
+		t.timer = time.NewTimer(d)
 	}
-	return nil
 }
 
-func restartTimer(timer *time.Timer, d time.Duration) *time.Timer {
-	if timer != nil {
-		timer.Reset(d)
-		return timer
+func (t *chanTimer) stop() {
+	switch {
+	case t.wheel != nil:
+		t.wheel.Stop()
+	case t.timer != nil:
+		t.timer.Stop()
 	}
-	return time.NewTimer(d)
 }
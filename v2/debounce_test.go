@@ -137,6 +137,22 @@ func TestDebounce_ZeroDelay(t *testing.T) {
 	}
 }
 
+func TestDebounce_ZeroDelayWithName(t *testing.T) {
+	in := make(chan int)
+	out := debounce.Chan(in, debounce.WithLimit(3), debounce.WithName("zero-delay"))
+
+	go func() {
+		for i := 0; i < 100; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	if result := collect(out, time.Second); len(result) != 100 {
+		t.Errorf("expected all 100 values without delay, got %d", len(result))
+	}
+}
+
 func BenchmarkDebounce_Insert(b *testing.B) {
 	in := make(chan int)
 	_ = debounce.Chan(in, debounce.WithDelay(100*time.Millisecond))
@@ -148,3 +164,40 @@ func BenchmarkDebounce_Insert(b *testing.B) {
 	b.StopTimer()
 	close(in)
 }
+
+func TestDebounce_AdaptiveDelay(t *testing.T) {
+	in := make(chan int)
+	out := debounce.Chan(in, debounce.WithDelay(500*time.Millisecond), debounce.WithAdaptiveDelay(3, 30*time.Millisecond, 500*time.Millisecond))
+
+	go func() {
+		in <- 1
+		time.Sleep(20 * time.Millisecond)
+		in <- 2
+		time.Sleep(20 * time.Millisecond)
+		in <- 3
+		time.Sleep(200 * time.Millisecond) // longer than adaptive delay, shorter than WithDelay
+		in <- 4
+		time.Sleep(200 * time.Millisecond)
+		close(in)
+	}()
+
+	expected := []int{3, 4}
+	result := collect(out, 1*time.Second)
+	if !slices.Equal(expected, result) {
+		t.Errorf("expected result = %v, got %v", expected, result)
+	}
+}
+
+func BenchmarkDebounce_InsertParallel(b *testing.B) {
+	in := make(chan int)
+	_ = debounce.Chan(in, debounce.WithDelay(100*time.Millisecond))
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			in <- i
+		}
+	})
+	b.StopTimer()
+	close(in)
+}
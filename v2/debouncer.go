@@ -1,39 +1,116 @@
 package debounce
 
+import (
+	"sync"
+	"time"
+)
+
 // Debouncer wraps a debounced channel of functions,
 // allowing callers to submit or wrap functions that will only be executed
 // according to the debounce configuration (e.g., delay, limit).
 type Debouncer struct {
-	inputCh     chan func()   // Channel to receive submitted functions
-	debouncedCh <-chan func() // Debounced output channel from Chan
+	inputCh chan submission // Channel to receive submitted functions
+	input   *Input[func()]  // Slot to receive submitted functions, if configured WithSlotInput
+	control Control         // Control bound to the underlying Chan
+
+	// Submissions received by Chan, but not executed yet, so a Ticket can be cancelled.
+	sendMu    sync.Mutex // Serializes sends, so history is in the order of receiving
+	mu        sync.Mutex
+	seq       uint64
+	history   []entry
+	withdrawn []*Ticket // Cancelled tickets, that the Chan goroutine has not withdrawn yet
+}
+
+// submission is a function submitted to Debouncer with an optional deadline.
+type submission struct {
+	f        func()
+	deadline time.Time
+	seq      uint64 // Sequence number of the submission in history
+}
+
+func (s submission) by() time.Time {
+	return s.deadline
 }
 
 // New creates a new Debouncer instance.
 // Submitted functions will be debounced according to the provided options,
 // such as WithDelay or WithLimit.
 //
-// Each debounced function is executed in its own goroutine to avoid blocking the Debouncer.
+// Each debounced function is executed in its own goroutine to avoid blocking the Debouncer,
+// unless WithExecutor is specified. With WithLimiter, execution also waits for a free slot.
+// WithSlotInput lets concurrent callers of Do submit functions without waiting for each other.
 func New(opts ...Option) *Debouncer {
-	inputCh := make(chan func())
-	debouncedCh := Chan(inputCh, opts...)
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
 
-	go func() {
-		for f := range debouncedCh {
-			// Execute function without blocking the debounce processing
+	dispatch := func(f func()) {
+		if options.executor != nil {
+			options.executor.Submit(f)
+		} else {
 			go f()
 		}
-	}()
+	}
+
+	d := &Debouncer{}
+
+	// Execute function without blocking the debounce processing
+	execute := func(f func()) {
+		if limiter := options.limiter; limiter != nil {
+			limiter.Acquire(d, func() {
+				dispatch(func() {
+					defer limiter.Release()
+					f()
+				})
+			})
+		} else {
+			dispatch(f)
+		}
+	}
 
-	return &Debouncer{
-		inputCh:     inputCh,
-		debouncedCh: debouncedCh,
+	WithControl(&d.control)(&options)
+	if options.slotInput {
+		d.input = NewInput[func()]()
+		debouncedCh := start(nil, d.input, options, hooks[func()]{})
+		go func() {
+			for f := range debouncedCh {
+				execute(f)
+			}
+		}()
+	} else {
+		d.inputCh = make(chan submission)
+		debouncedCh := start(d.inputCh, nil, options, hooks[submission]{deadline: submission.by, withdraw: d.withdraw})
+		go func() {
+			for s := range debouncedCh {
+				if f := d.settle(s); f != nil {
+					execute(f)
+				}
+			}
+		}()
 	}
+
+	return d
 }
 
 // Do submits a function f to be executed according to the debounce rules.
 // Only the most recent function may be executed, depending on delay and limit configuration.
 func (d *Debouncer) Do(f func()) {
-	d.inputCh <- f
+	if d.input != nil {
+		d.input.Send(f)
+	} else {
+		d.send(submission{f: f}, nil)
+	}
+}
+
+// DoBy submits f like Do, but the pending function is executed no later than deadline,
+// even if submissions keep coming. Coalesced submissions keep the earliest deadline among them.
+func (d *Debouncer) DoBy(f func(), deadline time.Time) {
+	if d.input != nil {
+		d.input.sendBy(f, deadline)
+	} else {
+		d.send(submission{f: f, deadline: deadline}, nil)
+	}
 }
 
 // Func returns a debounced wrapper of the given function f.
@@ -42,11 +119,40 @@ func (d *Debouncer) Do(f func()) {
 // if subsequent calls override it before the debounce conditions are met.
 func (d *Debouncer) Func(f func()) func() {
 	return func() {
-		d.inputCh <- f
+		d.Do(f)
 	}
 }
 
+// SetOptions asynchronously changes the debounce configuration (e.g., WithDelay or WithLimit)
+// of a running Debouncer. See Control.SetOptions for how the pending function is affected.
+func (d *Debouncer) SetOptions(opts ...Option) {
+	d.control.SetOptions(opts...)
+}
+
+// Pause holds execution of submitted functions. Submissions are still accepted,
+// but only the most recent one is retained until Resume. Pause returns once the Debouncer
+// is paused, so functions submitted after it are held.
+func (d *Debouncer) Pause() {
+	d.control.Pause()
+}
+
+// Resume ends a pause and immediately executes the most recent function submitted
+// during it, if any.
+func (d *Debouncer) Resume() {
+	d.control.Resume()
+}
+
+// State returns a snapshot of the Debouncer: whether a function is pending, how many
+// submissions were coalesced into it, when it is scheduled, and how many functions were executed.
+func (d *Debouncer) State() State {
+	return d.control.State()
+}
+
 // Closes underlying channel in Debouncer instance.
 func (d *Debouncer) Close() {
-	close(d.inputCh)
+	if d.input != nil {
+		d.input.Close()
+	} else {
+		close(d.inputCh)
+	}
 }
@@ -1,6 +1,8 @@
 package debounce_test
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,3 +19,88 @@ func BenchmarkDebounce_Do(b *testing.B) {
 	b.StopTimer()
 	debouncer.Close()
 }
+
+func BenchmarkDebounce_DoParallel(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []debounce.Option
+	}{
+		{"channel", nil},
+		{"slot", []debounce.Option{debounce.WithSlotInput()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			debouncer := debounce.New(append(bc.opts, debounce.WithDelay(100*time.Millisecond))...)
+			f := func() {}
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					debouncer.Do(f)
+				}
+			})
+			b.StopTimer()
+			debouncer.Close()
+		})
+	}
+}
+
+func TestDebouncer_ZeroDelay(t *testing.T) {
+	var wg sync.WaitGroup
+	var executed atomic.Int64
+	debouncer := debounce.New()
+	defer debouncer.Close()
+
+	wg.Add(1000)
+	for i := 0; i < 1000; i++ {
+		debouncer.Do(func() {
+			executed.Add(1)
+			wg.Done()
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("expected every function to be executed without delay, got %d", executed.Load())
+	}
+}
+
+func TestDebouncer_DoBy(t *testing.T) {
+	for _, bc := range []struct {
+		name string
+		opts []debounce.Option
+	}{
+		{"channel", nil},
+		{"slot", []debounce.Option{debounce.WithSlotInput()}},
+	} {
+		t.Run(bc.name, func(t *testing.T) {
+			done := make(chan time.Time, 1)
+			debouncer := debounce.New(append(bc.opts, debounce.WithDelay(100*time.Millisecond))...)
+			defer debouncer.Close()
+
+			f := func() { done <- time.Now() }
+			start := time.Now()
+			debouncer.DoBy(f, start.Add(50*time.Millisecond))
+
+			// Submissions keep coming, but the function is still executed by the deadline
+			for i := 0; i < 10; i++ {
+				time.Sleep(10 * time.Millisecond)
+				select {
+				case fired := <-done:
+					if elapsed := fired.Sub(start); elapsed < 50*time.Millisecond || elapsed > 80*time.Millisecond {
+						t.Errorf("expected execution at the deadline, got after %v", elapsed)
+					}
+					return
+				default:
+				}
+				debouncer.Do(f)
+			}
+			t.Error("expected execution by the deadline")
+		})
+	}
+}
@@ -54,3 +54,29 @@ func ExampleChan() {
 	time.Sleep(time.Second)
 	// Output: 4
 }
+
+func ExampleMachine() {
+	m := debounce.NewMachine[int](debounce.WithDelay(200 * time.Millisecond))
+	defer m.Stop()
+
+	events := make(chan int)
+	go func() {
+		for i := 1; i <= 4; i++ {
+			events <- i
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
+
+	for {
+		select {
+		case v := <-events:
+			m.Push(v)
+		case <-m.C():
+			if v, ok := m.Take(); ok {
+				fmt.Println(v)
+				return
+			}
+		}
+	}
+	// Output: 4
+}
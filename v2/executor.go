@@ -0,0 +1,96 @@
+package debounce
+
+import "sync"
+
+// Executor runs functions submitted to Debouncer. By default every execution gets its own
+// goroutine, which can be replaced with WithExecutor to bound the number of goroutines.
+type Executor interface {
+	Submit(fn func())
+}
+
+// WithExecutor makes Debouncer execute functions with e. It has no effect on Chan.
+func WithExecutor(e Executor) Option {
+	return func(options *options) {
+		options.executor = e
+	}
+}
+
+// Inline executes functions synchronously on the Debouncer goroutine, so the next
+// function is not emitted until the previous one returns.
+var Inline Executor = inline{}
+
+type inline struct{}
+
+func (inline) Submit(fn func()) {
+	fn()
+}
+
+// QueuePolicy defines what Pool does with a submitted function, when its queue is full.
+type QueuePolicy int
+
+const (
+	QueueBlock      QueuePolicy = iota // Submit waits for free space in the queue
+	QueueDiscard                       // Submit discards the function
+	QueueCallerRuns                    // Submit executes the function on the calling goroutine
+)
+
+// Pool is an Executor with a fixed number of worker goroutines and a bounded queue.
+type Pool struct {
+	queue  chan func()
+	policy QueuePolicy
+	wg     sync.WaitGroup
+}
+
+// NewPool starts workers goroutines, that execute functions from a queue of queueSize.
+// policy defines what happens to submitted functions when the queue is full.
+func NewPool(workers, queueSize int, policy QueuePolicy) *Pool {
+	p := &Pool{
+		queue:  make(chan func(), queueSize),
+		policy: policy,
+	}
+
+	p.wg.Add(workers)
+	for range workers {
+		go p.work()
+	}
+	return p
+}
+
+// NewSerial starts a single dedicated goroutine, that executes functions one by one
+// in submission order. Submit waits, if queueSize functions are already queued.
+func NewSerial(queueSize int) *Pool {
+	return NewPool(1, queueSize, QueueBlock)
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for fn := range p.queue {
+		fn()
+	}
+}
+
+// Submit queues fn for execution according to the queue policy.
+// It must not be called after Close.
+func (p *Pool) Submit(fn func()) {
+	switch p.policy {
+	case QueueDiscard:
+		select {
+		case p.queue <- fn:
+		default:
+		}
+	case QueueCallerRuns:
+		select {
+		case p.queue <- fn:
+		default:
+			fn()
+		}
+	default:
+		p.queue <- fn
+	}
+}
+
+// Close waits for queued functions to be executed and stops the workers.
+func (p *Pool) Close() {
+	close(p.queue)
+	p.wg.Wait()
+}
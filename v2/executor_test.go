@@ -0,0 +1,116 @@
+package debounce_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+func TestPool(t *testing.T) {
+	pool := debounce.NewPool(2, 10, debounce.QueueBlock)
+
+	var running, maxRunning, executed int32
+	for range 10 {
+		pool.Submit(func() {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&executed, 1)
+		})
+	}
+	pool.Close()
+
+	if executed != 10 {
+		t.Errorf("expected 10 executions, got %d", executed)
+	}
+	if maxRunning > 2 {
+		t.Errorf("expected at most 2 concurrent executions, got %d", maxRunning)
+	}
+}
+
+func TestPool_QueuePolicies(t *testing.T) {
+	block := make(chan struct{})
+
+	started := make(chan struct{})
+	discard := debounce.NewPool(1, 1, debounce.QueueDiscard)
+	discard.Submit(func() { close(started); <-block })
+	<-started
+
+	var executed int32
+	discard.Submit(func() { atomic.AddInt32(&executed, 1) }) // Queued
+	discard.Submit(func() { atomic.AddInt32(&executed, 1) }) // Discarded
+
+	started = make(chan struct{})
+	callerRuns := debounce.NewPool(1, 1, debounce.QueueCallerRuns)
+	callerRuns.Submit(func() { close(started); <-block })
+	<-started
+	callerRuns.Submit(func() {}) // Queued
+
+	var ranInCaller bool
+	callerRuns.Submit(func() { ranInCaller = true })
+	if !ranInCaller {
+		t.Error("expected function to be executed by caller, when queue is full")
+	}
+
+	close(block)
+	discard.Close()
+	callerRuns.Close()
+
+	if executed != 1 {
+		t.Errorf("expected 1 execution, got %d", executed)
+	}
+}
+
+func TestDebouncer_WithExecutor(t *testing.T) {
+	serial := debounce.NewSerial(10)
+
+	var wg sync.WaitGroup
+	var running, maxRunning int32
+
+	debouncers := make([]*debounce.Debouncer, 5)
+	for i := range debouncers {
+		debouncers[i] = debounce.New(debounce.WithDelay(10*time.Millisecond), debounce.WithExecutor(serial))
+		defer debouncers[i].Close()
+	}
+
+	wg.Add(len(debouncers))
+	for _, d := range debouncers {
+		d.Do(func() {
+			defer wg.Done()
+			if n := atomic.AddInt32(&running, 1); n > atomic.LoadInt32(&maxRunning) {
+				atomic.StoreInt32(&maxRunning, n)
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		})
+	}
+
+	wg.Wait()
+	serial.Close()
+
+	if maxRunning != 1 {
+		t.Errorf("expected serial executions, got %d concurrent", maxRunning)
+	}
+}
+
+func TestDebouncer_InlineExecutor(t *testing.T) {
+	var counter int32
+	debouncer := debounce.New(debounce.WithDelay(10*time.Millisecond), debounce.WithExecutor(debounce.Inline))
+	defer debouncer.Close()
+
+	debouncer.Do(func() { atomic.AddInt32(&counter, 1) })
+
+	time.Sleep(50 * time.Millisecond)
+	if c := atomic.LoadInt32(&counter); c != 1 {
+		t.Errorf("expected counter = 1, got %d", c)
+	}
+}
@@ -0,0 +1,122 @@
+package debounce
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Input is a multi-producer input for a debounced channel, an alternative to sending values
+// through a Go channel. Send stores the value in a latest-value slot and wakes the Chan
+// goroutine only if it is not already woken, so producers neither wait for the goroutine
+// nor for each other.
+//
+// Values overwritten before the goroutine picks them up are coalesced right away,
+// but every Send is still counted by WithLimit. Input must be consumed by a single Chan.
+type Input[T any] struct {
+	value    atomic.Value // Latest value, stored as slot[T]
+	count    atomic.Int64 // Number of values sent since the last take
+	signaled atomic.Bool  // Whether the goroutine is woken and has not taken values yet
+
+	// Earliest deadline of values sent since the last take, zero if there is none.
+	// Taken together with count under mu, so it is never separated from its value.
+	mu       sync.Mutex
+	deadline time.Time
+
+	wake      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// slot wraps values in Input, so values of interface types have the same concrete type.
+type slot[T any] struct {
+	v T
+}
+
+// NewInput creates an Input. Use Input.Chan to debounce values sent to it.
+func NewInput[T any]() *Input[T] {
+	return &Input[T]{
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+}
+
+// WithSlotInput makes Debouncer.Do store functions in an Input instead of sending them
+// through an unbuffered channel, so concurrent callers do not serialize on the Debouncer goroutine.
+// It has no effect on Chan, use Input.Chan instead.
+func WithSlotInput() Option {
+	return func(options *options) {
+		options.slotInput = true
+	}
+}
+
+// Send records v as the latest value. It never blocks.
+// Values sent after Close are dropped.
+func (in *Input[T]) Send(v T) {
+	in.value.Store(slot[T]{v})
+	in.count.Add(1)
+	in.signal()
+}
+
+// sendBy is Send, that also makes the pending value settle no later than deadline.
+func (in *Input[T]) sendBy(v T, deadline time.Time) {
+	in.mu.Lock()
+	in.value.Store(slot[T]{v})
+	if in.deadline.IsZero() || deadline.Before(in.deadline) {
+		in.deadline = deadline
+	}
+	in.count.Add(1)
+	in.mu.Unlock()
+	in.signal()
+}
+
+func (in *Input[T]) signal() {
+	// Only one producer signals, so wake never blocks
+	if !in.signaled.Load() && in.signaled.CompareAndSwap(false, true) {
+		in.wake <- struct{}{}
+	}
+}
+
+// Close closes the input. The debounced channel emits the pending value, if any, and is closed.
+// Like closing a channel, Close must not be called concurrently with Send.
+func (in *Input[T]) Close() {
+	in.closeOnce.Do(func() {
+		close(in.done)
+	})
+}
+
+// Chan returns a debounced output channel of values sent to in. It accepts the same
+// options as Chan, but never returns the input unmodified, as there is no channel to return.
+func (in *Input[T]) Chan(opts ...Option) <-chan T {
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return start(nil, in, options, hooks[T]{})
+}
+
+// take returns the latest value, the number of values sent since the previous take
+// and their earliest deadline.
+func (in *Input[T]) take() (T, int, time.Time, bool) {
+	in.signaled.Store(false) // Sends after this point signal again
+
+	in.mu.Lock()
+	n := in.count.Swap(0)
+	deadline := in.deadline
+	in.deadline = time.Time{}
+	in.mu.Unlock()
+
+	if n == 0 {
+		var zero T
+		return zero, 0, deadline, false
+	}
+	return in.value.Load().(slot[T]).v, int(n), deadline, true
+}
+
+// signals returns channels of in, or nil channels if in is nil.
+func (in *Input[T]) signals() (wake, done <-chan struct{}) {
+	if in == nil {
+		return nil, nil
+	}
+	return in.wake, in.done
+}
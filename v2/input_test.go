@@ -0,0 +1,125 @@
+package debounce_test
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+func TestInput_LastValueOnly(t *testing.T) {
+	in := debounce.NewInput[int]()
+	out := in.Chan(debounce.WithDelay(100 * time.Millisecond))
+
+	go func() {
+		in.Send(1)
+		in.Send(2)
+		time.Sleep(20 * time.Millisecond)
+		in.Send(3)
+		time.Sleep(200 * time.Millisecond)
+		in.Close()
+	}()
+
+	result := collect(out, 500*time.Millisecond)
+	expected := []int{3}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestInput_LimitCountsCoalescedValues(t *testing.T) {
+	in := debounce.NewInput[int]()
+	out := in.Chan(debounce.WithDelay(time.Second), debounce.WithLimit(100))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				in.Send(j)
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case v := <-out:
+		if v != 9 {
+			t.Errorf("expected last value 9, got %d", v)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Error("expected value to be emitted when limit is reached")
+	}
+	in.Close()
+}
+
+func TestInput_CloseFlushes(t *testing.T) {
+	in := debounce.NewInput[string]()
+	out := in.Chan(debounce.WithDelay(time.Second))
+
+	in.Send("a")
+	in.Send("b")
+	in.Close()
+
+	result := collect(out, 500*time.Millisecond)
+	expected := []string{"b"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestDebouncer_SlotInput(t *testing.T) {
+	var called int32
+	debouncer := debounce.New(debounce.WithDelay(50*time.Millisecond), debounce.WithSlotInput())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				debouncer.Do(func() { atomic.AddInt32(&called, 1) })
+			}
+		}()
+	}
+	wg.Wait()
+
+	time.Sleep(150 * time.Millisecond)
+	if n := atomic.LoadInt32(&called); n != 1 {
+		t.Errorf("expected 1 call, got %d", n)
+	}
+	if s := debouncer.State(); s.Fires != 1 {
+		t.Errorf("expected 1 fire, got %+v", s)
+	}
+	debouncer.Close()
+}
+
+func BenchmarkInput_Send(b *testing.B) {
+	in := debounce.NewInput[int]()
+	_ = in.Chan(debounce.WithDelay(100 * time.Millisecond))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		in.Send(i)
+	}
+	b.StopTimer()
+	in.Close()
+}
+
+func BenchmarkInput_SendParallel(b *testing.B) {
+	in := debounce.NewInput[int]()
+	_ = in.Chan(debounce.WithDelay(100 * time.Millisecond))
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			in.Send(i)
+		}
+	})
+	b.StopTimer()
+	in.Close()
+}
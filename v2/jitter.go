@@ -0,0 +1,43 @@
+package debounce
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// WithJitter adds a random duration in [0, jitter) to the delay of every value window,
+// so debouncers with the same delay, triggered at once, do not emit in lockstep.
+func WithJitter(jitter time.Duration) Option {
+	return func(options *options) {
+		options.jitter = jitter
+	}
+}
+
+// WithJitterFraction is like WithJitter, but the bound is the given fraction of the delay.
+func WithJitterFraction(fraction float64) Option {
+	return func(options *options) {
+		options.jitterFraction = fraction
+	}
+}
+
+// WithRand sets the random source of jitter, for example a seeded one for reproducible tests.
+// The source is used by the Chan goroutine, so it must not be shared with other debouncers.
+// By default, the global source of math/rand/v2 is used.
+func WithRand(r *rand.Rand) Option {
+	return func(options *options) {
+		options.rand = r
+	}
+}
+
+// drawJitter returns a random addition to the delay of a new value window.
+func (o *options) drawJitter() time.Duration {
+	bound := int64(o.jitter + time.Duration(o.jitterFraction*float64(o.delay)))
+	switch {
+	case bound <= 0:
+		return 0
+	case o.rand != nil:
+		return time.Duration(o.rand.Int64N(bound))
+	default:
+		return time.Duration(rand.Int64N(bound))
+	}
+}
@@ -0,0 +1,59 @@
+package debounce_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+func TestMachine_Jitter(t *testing.T) {
+	newMachine := func() *debounce.Machine[int] {
+		return debounce.NewMachine[int](
+			debounce.WithDelay(100*time.Millisecond),
+			debounce.WithJitter(10*time.Millisecond),
+			debounce.WithJitterFraction(0.1),
+			debounce.WithRand(rand.New(rand.NewPCG(1, 2))),
+		)
+	}
+
+	m, expected := newMachine(), newMachine()
+	defer m.Stop()
+	defer expected.Stop()
+
+	for i := 0; i < 10; i++ {
+		m.Push(i)
+		expected.Push(i)
+
+		s, e := m.State(), expected.State()
+		delay := s.FireAt.Sub(s.First)
+		if delay < 100*time.Millisecond || delay >= 120*time.Millisecond {
+			t.Fatalf("expected delay in [100ms, 120ms), got %v", delay)
+		}
+		if d := e.FireAt.Sub(e.First); d-delay > time.Millisecond || delay-d > time.Millisecond {
+			t.Fatalf("expected seeded delay %v, got %v", d, delay)
+		}
+
+		m.Flush()
+		expected.Flush()
+	}
+}
+
+func TestDebounce_Jitter(t *testing.T) {
+	in := make(chan int)
+	out := debounce.Chan(in, debounce.WithJitter(50*time.Millisecond))
+
+	start := time.Now()
+	in <- 1
+
+	select {
+	case <-out:
+		if elapsed := time.Since(start); elapsed >= 100*time.Millisecond {
+			t.Errorf("expected value within jitter bound, got %v", elapsed)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Error("expected value to be emitted")
+	}
+	close(in)
+}
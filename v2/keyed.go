@@ -0,0 +1,282 @@
+package debounce
+
+import (
+	"container/heap"
+	"time"
+)
+
+// ChanBy wraps an input channel and debounces values of every key independently: each key
+// has its own WithDelay and WithLimit window and emits its last value when it goes quiet.
+// A key is forgotten as soon as its value is emitted, so idle keys take no memory.
+//
+// Values of different keys are emitted in the order their windows settle.
+// WithJitter, WithAlign, WithOutputPolicy, WithOnClose and WithScheduler are supported, other options are ignored.
+//
+// If delay is 0 and no output policy is configured, the function returns the input channel unmodified.
+func ChanBy[T any, K comparable](in <-chan T, key func(T) K, opts ...Option) <-chan T {
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// Optimization: no debouncing if delay is zero
+	if options.delay == 0 && options.jitter == 0 && options.alignPeriod == 0 && options.output == OutputBlock && options.outputSize <= 1 {
+		return in
+	}
+
+	out := make(chan T, max(options.outputSize, 1))
+	go func() {
+		defer close(out)
+
+		w := newKeyWindows[T, K](options)
+		defer w.timer.stop()
+
+		emit := func(v T) {
+			deliver(out, v, options.output)
+		}
+
+		for {
+			select {
+			case v, ok := <-in:
+				now := time.Now()
+				if !ok {
+					in = nil
+					switch options.onClose {
+					case CloseDrop:
+						return
+					case CloseEmitAfterDelay:
+						// Pending values settle as usual
+					default:
+						// Input channel closed — emit all pending values.
+						for w.Len() > 0 {
+							emit(w.pop().value)
+						}
+					}
+				} else if v, ok := w.push(key(v), v, now); ok {
+					// Force emit if limit reached
+					emit(v)
+				}
+				w.rearm(now)
+			case <-w.timer.C():
+				now := time.Now()
+				w.expire(now)
+				for w.settled(now) {
+					emit(w.pop().value)
+				}
+				w.rearm(now)
+			}
+
+			if in == nil && w.Len() == 0 {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// ChanLatestByKey wraps an input channel and emits, once per quiet period, a map with the last
+// value of every key received since the previous emission. The window follows the same WithDelay,
+// WithLimit and WithAdaptiveDelay rules as Chan, counting values of all keys.
+// The emitted map is owned by the receiver, it is not reused.
+//
+// WithOutputPolicy, WithOnClose and WithScheduler are supported, other options are ignored.
+// With OutputReplace undelivered maps are merged into the newest one, so no key is lost,
+// while OutputDropNew drops the whole map of a window, that does not fit into the output buffer.
+func ChanLatestByKey[T any, K comparable](in <-chan T, key func(T) K, opts ...Option) <-chan map[K]T {
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	out := make(chan map[K]T, max(options.outputSize, 1))
+	go func() {
+		defer close(out)
+
+		// Machine settles the window, values are collected in dirty
+		m := newMachine[struct{}](options)
+		defer m.Stop()
+
+		dirty := make(map[K]T)
+		emit := func(_ struct{}, ok bool) {
+			if ok {
+				deliverMerged(out, dirty, options.output, mergeLatest[K, T])
+				dirty = make(map[K]T)
+			}
+		}
+
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					in = nil
+					switch options.onClose {
+					case CloseDrop:
+						return
+					case CloseEmitAfterDelay:
+						// Pending values settle as usual
+					default:
+						// Input channel closed — emit pending values.
+						emit(m.Flush())
+					}
+					break
+				}
+
+				dirty[key(v)] = v
+				if m.Push(struct{}{}) {
+					// Force emit if limit reached
+					emit(m.Take())
+				}
+			case <-m.C():
+				emit(m.Take())
+			}
+
+			if in == nil && !m.Pending() {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// mergeLatest adds keys of the dropped map, that are missing in the newer map v.
+func mergeLatest[K comparable, T any](dropped, v map[K]T) map[K]T {
+	for k, old := range dropped {
+		if _, ok := v[k]; !ok {
+			v[k] = old
+		}
+	}
+	return v
+}
+
+// ChanGroupBy composes per-key and global debouncing: values of every key are debounced
+// independently with keyOpts, like in ChanBy, and settled values of all keys are debounced
+// together with opts, like in ChanLatestByKey. A storm across many keys produces at most one
+// map per global window, holding the settled value of every key.
+func ChanGroupBy[T any, K comparable](in <-chan T, key func(T) K, keyOpts []Option, opts ...Option) <-chan map[K]T {
+	return ChanLatestByKey(ChanBy(in, key, keyOpts...), key, opts...)
+}
+
+// keyWindow is a pending value of a single key.
+type keyWindow[T any, K comparable] struct {
+	key    K
+	value  T
+	count  int           // Number of delay resets
+	fireAt time.Time     // Time the value settles
+	jitter time.Duration // Random addition to the delay
+	index  int           // Index in keyWindows heap
+}
+
+// keyWindows is a min-heap of pending windows by fireAt with an index by key.
+// All windows share a single timer, armed for the earliest window.
+type keyWindows[T any, K comparable] struct {
+	options options
+	windows []*keyWindow[T, K]
+	keys    map[K]*keyWindow[T, K]
+
+	timer   *chanTimer
+	timerAt time.Time // Time the timer is armed for, zero if it is not armed
+}
+
+func newKeyWindows[T any, K comparable](options options) *keyWindows[T, K] {
+	return &keyWindows[T, K]{
+		options: options,
+		keys:    make(map[K]*keyWindow[T, K]),
+		timer:   newChanTimer(options.scheduler),
+	}
+}
+
+// push records v as the pending value of key k and restarts its delay.
+// It returns the value, if the limit of the key is reached.
+func (w *keyWindows[T, K]) push(k K, v T, now time.Time) (T, bool) {
+	kw, ok := w.keys[k]
+	if !ok {
+		kw = &keyWindow[T, K]{key: k, jitter: w.options.drawJitter()}
+	}
+	kw.value = v
+	kw.count++
+
+	if w.options.limitReached(kw.count) {
+		if ok {
+			w.remove(kw)
+		}
+		return v, true
+	}
+
+	kw.fireAt = align(now.Add(w.options.delay+kw.jitter), w.options.alignPeriod, w.options.alignOffset)
+	if ok {
+		heap.Fix(w, kw.index)
+	} else {
+		w.keys[k] = kw
+		heap.Push(w, kw)
+	}
+
+	var zero T
+	return zero, false
+}
+
+// pop removes and returns the earliest window.
+func (w *keyWindows[T, K]) pop() *keyWindow[T, K] {
+	kw := heap.Pop(w).(*keyWindow[T, K])
+	delete(w.keys, kw.key)
+	return kw
+}
+
+func (w *keyWindows[T, K]) remove(kw *keyWindow[T, K]) {
+	heap.Remove(w, kw.index)
+	delete(w.keys, kw.key)
+}
+
+// settled reports whether the earliest window has settled by now.
+func (w *keyWindows[T, K]) settled(now time.Time) bool {
+	return w.Len() > 0 && !now.Before(w.windows[0].fireAt)
+}
+
+// expire marks the timer as fired, if it was due. Timer could signal after it was rescheduled.
+func (w *keyWindows[T, K]) expire(now time.Time) {
+	if !now.Before(w.timerAt) {
+		w.timerAt = time.Time{}
+	}
+}
+
+// rearm arms the timer for the earliest window. Timer is not rescheduled when the
+// earliest window moves later, it fires early and is re-armed instead.
+func (w *keyWindows[T, K]) rearm(now time.Time) {
+	switch {
+	case w.Len() == 0:
+		if !w.timerAt.IsZero() {
+			w.timer.stop()
+			w.timerAt = time.Time{}
+		}
+	case w.timerAt.IsZero() || w.windows[0].fireAt.Before(w.timerAt):
+		w.timerAt = w.windows[0].fireAt
+		w.timer.reset(w.timerAt.Sub(now))
+	}
+}
+
+// Len, Less, Swap, Push and Pop implement heap.Interface.
+
+func (w *keyWindows[T, K]) Len() int { return len(w.windows) }
+
+func (w *keyWindows[T, K]) Less(i, j int) bool {
+	return w.windows[i].fireAt.Before(w.windows[j].fireAt)
+}
+
+func (w *keyWindows[T, K]) Swap(i, j int) {
+	w.windows[i], w.windows[j] = w.windows[j], w.windows[i]
+	w.windows[i].index = i
+	w.windows[j].index = j
+}
+
+func (w *keyWindows[T, K]) Push(x any) {
+	kw := x.(*keyWindow[T, K])
+	kw.index = len(w.windows)
+	w.windows = append(w.windows, kw)
+}
+
+func (w *keyWindows[T, K]) Pop() any {
+	n := len(w.windows) - 1
+	kw := w.windows[n]
+	w.windows[n] = nil
+	w.windows = w.windows[:n]
+	return kw
+}
@@ -0,0 +1,197 @@
+package debounce_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+type event struct {
+	key   string
+	value int
+}
+
+func eventKey(e event) string { return e.key }
+
+func TestChanBy_IndependentKeys(t *testing.T) {
+	in := make(chan event)
+	out := debounce.ChanBy(in, eventKey, debounce.WithDelay(100*time.Millisecond))
+
+	go func() {
+		in <- event{"a", 1}
+		in <- event{"b", 1}
+		in <- event{"a", 2}
+		time.Sleep(50 * time.Millisecond)
+		in <- event{"b", 2} // Only b is reset
+		time.Sleep(200 * time.Millisecond)
+		close(in)
+	}()
+
+	result := collect(out, time.Second)
+	expected := []event{{"a", 2}, {"b", 2}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestChanBy_Limit(t *testing.T) {
+	in := make(chan event)
+	out := debounce.ChanBy(in, eventKey, debounce.WithDelay(time.Second), debounce.WithLimit(2))
+
+	go func() {
+		in <- event{"a", 1}
+		in <- event{"b", 1}
+		in <- event{"a", 2} // Limit of a reached
+		time.Sleep(50 * time.Millisecond)
+		close(in)
+	}()
+
+	result := collect(out, 500*time.Millisecond)
+	expected := []event{{"a", 2}, {"b", 1}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestChanBy_OnClose(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		policy   debounce.ClosePolicy
+		expected []string
+	}{
+		{"emit", debounce.CloseEmit, []string{"a", "b", "c"}},
+		{"drop", debounce.CloseDrop, nil},
+		{"emit after delay", debounce.CloseEmitAfterDelay, []string{"a", "b", "c"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			in := make(chan event)
+			out := debounce.ChanBy(in, eventKey, debounce.WithDelay(50*time.Millisecond), debounce.WithOnClose(tc.policy))
+
+			for _, k := range []string{"c", "a", "b"} {
+				in <- event{k, 1}
+			}
+			close(in)
+
+			var keys []string
+			for _, e := range collect(out, 500*time.Millisecond) {
+				keys = append(keys, e.key)
+			}
+			sort.Strings(keys)
+			if !reflect.DeepEqual(keys, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, keys)
+			}
+		})
+	}
+}
+
+func TestChanBy_ZeroDelay(t *testing.T) {
+	in := make(chan event)
+	if out := debounce.ChanBy(in, eventKey); out != (<-chan event)(in) {
+		t.Error("expected input channel to be returned unmodified")
+	}
+}
+
+func BenchmarkChanBy_Insert(b *testing.B) {
+	in := make(chan event)
+	_ = debounce.ChanBy(in, eventKey, debounce.WithDelay(100*time.Millisecond))
+
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		in <- event{keys[i%len(keys)], i}
+	}
+	b.StopTimer()
+	close(in)
+}
+
+func TestChanLatestByKey(t *testing.T) {
+	in := make(chan event)
+	out := debounce.ChanLatestByKey(in, eventKey, debounce.WithDelay(100*time.Millisecond))
+
+	go func() {
+		in <- event{"a", 1}
+		in <- event{"b", 1}
+		in <- event{"a", 2}
+		time.Sleep(200 * time.Millisecond)
+		in <- event{"c", 1}
+		close(in)
+	}()
+
+	result := collect(out, time.Second)
+	expected := []map[string]event{
+		{"a": {"a", 2}, "b": {"b", 1}},
+		{"c": {"c", 1}},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestChanLatestByKey_Limit(t *testing.T) {
+	in := make(chan event)
+	out := debounce.ChanLatestByKey(in, eventKey, debounce.WithDelay(time.Second), debounce.WithLimit(3), debounce.WithOnClose(debounce.CloseDrop))
+
+	go func() {
+		in <- event{"a", 1}
+		in <- event{"b", 1}
+		in <- event{"a", 2} // Limit reached, counting values of all keys
+		in <- event{"b", 2}
+		close(in)
+	}()
+
+	result := collect(out, 500*time.Millisecond)
+	expected := []map[string]event{
+		{"a": {"a", 2}, "b": {"b", 1}},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestChanLatestByKey_OutputReplace(t *testing.T) {
+	in := make(chan event)
+	out := debounce.ChanLatestByKey(in, eventKey, debounce.WithDelay(time.Second), debounce.WithLimit(1),
+		debounce.WithOutputPolicy(debounce.OutputReplace, 1), debounce.WithOnClose(debounce.CloseDrop))
+
+	// Consumer does not receive, while windows are emitted
+	in <- event{"a", 1}
+	in <- event{"b", 1}
+	in <- event{"a", 2}
+	close(in)
+
+	result := collect(out, 500*time.Millisecond)
+	expected := []map[string]event{
+		{"a": {"a", 2}, "b": {"b", 1}},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected undelivered maps to be merged into %v, got %v", expected, result)
+	}
+}
+
+func TestChanGroupBy(t *testing.T) {
+	in := make(chan event)
+	out := debounce.ChanGroupBy(in, eventKey,
+		[]debounce.Option{debounce.WithDelay(20 * time.Millisecond)},
+		debounce.WithDelay(100*time.Millisecond),
+	)
+
+	go func() {
+		in <- event{"a", 1}
+		in <- event{"a", 2}
+		time.Sleep(50 * time.Millisecond) // a settles, global window waits for more keys
+		in <- event{"b", 1}
+		time.Sleep(300 * time.Millisecond)
+		close(in)
+	}()
+
+	result := collect(out, time.Second)
+	expected := []map[string]event{
+		{"a": {"a", 2}, "b": {"b", 1}},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
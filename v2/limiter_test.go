@@ -0,0 +1,117 @@
+package debounce_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+func TestLimiter(t *testing.T) {
+	limiter := debounce.NewLimiter(2)
+
+	var wg sync.WaitGroup
+	var running, maxRunning int32
+
+	f := func() {
+		defer wg.Done()
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	}
+
+	wg.Add(10)
+	for range 10 {
+		debouncer := debounce.New(debounce.WithDelay(time.Millisecond), debounce.WithLimiter(limiter))
+		defer debouncer.Close()
+		debouncer.Do(f)
+	}
+	wg.Wait()
+
+	if maxRunning != 2 {
+		t.Errorf("expected at most 2 concurrent executions, got %d", maxRunning)
+	}
+
+	stats := limiter.Stats()
+	if stats.Executions != 10 || stats.Waiting != 0 || stats.WaitMax == 0 || stats.WaitTotal < stats.WaitMax {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+// semaphore is a Semaphore, that is not a *Limiter, like Limiter of v1 package.
+type semaphore struct {
+	limiter  *debounce.Limiter
+	acquired atomic.Int32
+}
+
+func (s *semaphore) Acquire(owner any, start func()) {
+	s.acquired.Add(1)
+	s.limiter.Acquire(owner, start)
+}
+
+func (s *semaphore) Release() {
+	s.limiter.Release()
+}
+
+func TestLimiter_Semaphore(t *testing.T) {
+	s := &semaphore{limiter: debounce.NewLimiter(1)}
+	done := make(chan struct{}, 3)
+
+	for range 3 {
+		debouncer := debounce.New(debounce.WithDelay(time.Millisecond), debounce.WithLimiter(s), debounce.WithExecutor(debounce.Inline))
+		defer debouncer.Close()
+		debouncer.Do(func() { done <- struct{}{} })
+	}
+	for range 3 {
+		<-done
+	}
+
+	if n := s.acquired.Load(); n != 3 {
+		t.Errorf("expected 3 acquired slots, got %d", n)
+	}
+	for s.limiter.Stats().Running != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if stats := s.limiter.Stats(); stats.Executions != 3 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestLimiter_OwnerOrder(t *testing.T) {
+	for range 100 {
+		limiter := debounce.NewLimiter(2)
+		limiter.Acquire(new(int), func() {})
+		limiter.Acquire(new(int), func() {})
+
+		var mu sync.Mutex
+		var order []int
+		var wg sync.WaitGroup
+		owner := new(int)
+		for n := 1; n <= 2; n++ {
+			wg.Add(1)
+			limiter.Acquire(owner, func() {
+				defer wg.Done()
+				mu.Lock()
+				order = append(order, n)
+				mu.Unlock()
+			})
+		}
+
+		// Both slots are released at once, executions of owner still start in order
+		go limiter.Release()
+		go limiter.Release()
+		wg.Wait()
+
+		if order[0] != 1 || order[1] != 2 {
+			t.Fatalf("expected executions of one owner to start in order, got %v", order)
+		}
+	}
+}
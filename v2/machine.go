@@ -0,0 +1,194 @@
+package debounce
+
+import "time"
+
+// Machine is a goroutine-free debounce state machine with the same WithDelay, WithLimit
+// and WithAdaptiveDelay logic as Chan. It lets callers debounce values inside their own
+// select loop without extra goroutines or channels:
+//
+//	for {
+//		select {
+//		case v := <-events:
+//			m.Push(v)
+//		case <-m.C():
+//			if v, ok := m.Take(); ok {
+//				handle(v)
+//			}
+//		}
+//	}
+//
+// Options that control a running Chan (WithControl, WithGate, WithName) are ignored.
+// With a shared WithScheduler, Machine uses neither goroutines nor runtime timers.
+// Machine is not safe for concurrent use.
+type Machine[T any] struct {
+	options options
+	timer   *chanTimer
+
+	value    T             // Last pushed value
+	pending  bool          // Whether a value is waiting to be taken
+	count    int           // Number of delay resets since last take
+	first    time.Time     // Time the pending value window started
+	fireAt   time.Time     // Time the pending value settles, zero if not scheduled
+	fires    uint64        // Number of taken values
+	jitter   time.Duration // Random addition to the delay of the pending value
+	deadline time.Time     // Earliest deadline of the pending value, zero if there is none
+}
+
+// NewMachine creates a Machine configured with options.
+func NewMachine[T any](opts ...Option) *Machine[T] {
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return newMachine[T](options)
+}
+
+func newMachine[T any](options options) *Machine[T] {
+	return &Machine[T]{
+		options: options,
+		timer:   newChanTimer(options.scheduler),
+	}
+}
+
+// C returns a channel that receives a value when the pending value may have settled.
+// It is only a hint, so the result of Take must be checked. Call C on every loop
+// iteration, as the returned channel can change after Push.
+func (m *Machine[T]) C() <-chan time.Time {
+	return m.timer.C()
+}
+
+// Push records v as the pending value and restarts the delay.
+// It reports whether the value can be taken right away: the limit is reached or there is no delay.
+func (m *Machine[T]) Push(v T) bool {
+	return m.push(v, 1, time.Time{})
+}
+
+// PushBy is like Push, but the pending value settles no later than deadline.
+// Coalesced values keep the earliest deadline among them.
+func (m *Machine[T]) PushBy(v T, deadline time.Time) bool {
+	return m.push(v, 1, deadline)
+}
+
+// push records v, that coalesces n values sent since the previous push,
+// and deadline of the pending value, if it is not zero.
+func (m *Machine[T]) push(v T, n int, deadline time.Time) bool {
+	now := time.Now()
+	if m.options.adaptive != nil {
+		m.options.adaptive.observe(now, m.pending)
+	}
+	if !m.pending {
+		m.first = now
+		m.jitter = m.options.drawJitter()
+	}
+
+	m.value = v
+	m.pending = true
+	if !deadline.IsZero() && (m.deadline.IsZero() || deadline.Before(m.deadline)) {
+		m.deadline = deadline
+	}
+
+	// On every new value, increment the reset count.
+	m.count += n
+
+	return m.rearm(now)
+}
+
+// Take returns the pending value and resets the machine, if the value has settled:
+// the delay passed since the last Push or the limit was reached.
+func (m *Machine[T]) Take() (T, bool) {
+	// Timer could signal after it was stopped or rescheduled
+	if !m.pending || m.fireAt.IsZero() || time.Now().Before(m.fireAt) {
+		var zero T
+		return zero, false
+	}
+	return m.Flush()
+}
+
+// Flush returns the pending value regardless of delay and limit, and resets the machine.
+func (m *Machine[T]) Flush() (T, bool) {
+	v, ok := m.value, m.pending
+	if ok {
+		var zero T
+		m.value = zero
+		m.pending = false
+		m.count = 0
+		m.deadline = time.Time{}
+		m.fires++
+		m.stopTimer()
+	}
+	return v, ok
+}
+
+// drop discards the pending value without taking it.
+func (m *Machine[T]) drop() {
+	var zero T
+	m.value = zero
+	m.pending = false
+	m.count = 0
+	m.deadline = time.Time{}
+	m.stopTimer()
+}
+
+// Pending reports whether a value is waiting to be taken.
+func (m *Machine[T]) Pending() bool {
+	return m.pending
+}
+
+// State returns a snapshot of the machine.
+func (m *Machine[T]) State() State {
+	state := State{
+		Pending: m.pending,
+		Paused:  m.options.paused,
+		Calls:   m.count,
+		FireAt:  m.fireAt,
+		Fires:   m.fires,
+	}
+	if m.pending {
+		state.First = m.first
+	}
+	return state
+}
+
+// Stop stops the machine timer. The pending value can still be flushed.
+func (m *Machine[T]) Stop() {
+	m.stopTimer()
+}
+
+// rearm re-evaluates the pending value after Push or configuration change.
+// It reports whether the value can be taken right away.
+func (m *Machine[T]) rearm(now time.Time) bool {
+	switch {
+	case !m.pending:
+		return false
+	case m.options.paused:
+		// Paused machine only retains the value until resumed
+		m.stopTimer()
+		return false
+	case m.options.limitReached(m.count):
+		// Force settle if limit reached, C receives immediately
+		m.schedule(now, 0)
+		return true
+	default:
+		fireAt := align(now.Add(m.options.currentDelay()+m.jitter), m.options.alignPeriod, m.options.alignOffset)
+		if !m.deadline.IsZero() && m.deadline.Before(fireAt) {
+			fireAt = m.deadline
+		}
+		if !fireAt.After(now) {
+			// Zero delay or passed deadline, value settles without waiting for C
+			m.schedule(now, 0)
+			return true
+		}
+		m.schedule(now, fireAt.Sub(now))
+		return false
+	}
+}
+
+func (m *Machine[T]) schedule(now time.Time, delay time.Duration) {
+	m.fireAt = now.Add(delay)
+	m.timer.reset(delay)
+}
+
+func (m *Machine[T]) stopTimer() {
+	m.timer.stop()
+	m.fireAt = time.Time{}
+}
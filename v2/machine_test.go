@@ -0,0 +1,115 @@
+package debounce_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+func TestMachine_SelectLoop(t *testing.T) {
+	m := debounce.NewMachine[int](debounce.WithDelay(100 * time.Millisecond))
+	defer m.Stop()
+
+	events := make(chan int)
+	go func() {
+		events <- 1
+		time.Sleep(50 * time.Millisecond)
+		events <- 2
+		time.Sleep(150 * time.Millisecond)
+		events <- 3
+		time.Sleep(150 * time.Millisecond)
+		close(events)
+	}()
+
+	var result []int
+	for events != nil || m.Pending() {
+		select {
+		case v, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			m.Push(v)
+		case <-m.C():
+			if v, ok := m.Take(); ok {
+				result = append(result, v)
+			}
+		}
+	}
+
+	expected := []int{2, 3}
+	if !slices.Equal(expected, result) {
+		t.Errorf("expected result = %v, got %v", expected, result)
+	}
+}
+
+func TestMachine_Limit(t *testing.T) {
+	m := debounce.NewMachine[int](debounce.WithDelay(time.Second), debounce.WithLimit(2))
+	defer m.Stop()
+
+	if m.Push(1) {
+		t.Error("expected first push to not reach limit")
+	}
+	if _, ok := m.Take(); ok {
+		t.Error("expected value to not be settled before delay")
+	}
+	if !m.Push(2) {
+		t.Error("expected second push to reach limit")
+	}
+
+	if s := m.State(); !s.Pending || s.Calls != 2 {
+		t.Errorf("expected 2 pending values, got %+v", s)
+	}
+
+	if v, ok := m.Take(); !ok || v != 2 {
+		t.Errorf("expected to take 2, got %v, %v", v, ok)
+	}
+	if s := m.State(); s.Pending || s.Fires != 1 {
+		t.Errorf("expected 1 fire without pending values, got %+v", s)
+	}
+}
+
+func TestMachine_Flush(t *testing.T) {
+	m := debounce.NewMachine[int](debounce.WithDelay(time.Second))
+	defer m.Stop()
+
+	if _, ok := m.Flush(); ok {
+		t.Error("expected nothing to flush")
+	}
+
+	m.Push(1)
+	if v, ok := m.Flush(); !ok || v != 1 {
+		t.Errorf("expected to flush 1, got %v, %v", v, ok)
+	}
+	if m.Pending() {
+		t.Error("expected no pending value after flush")
+	}
+}
+
+func TestMachine_PushBy(t *testing.T) {
+	m := debounce.NewMachine[int](debounce.WithDelay(time.Second))
+	defer m.Stop()
+
+	start := time.Now()
+	m.PushBy(1, start.Add(50*time.Millisecond))
+	m.PushBy(2, start.Add(200*time.Millisecond))
+	m.Push(3)
+
+	// Coalesced values keep the earliest deadline
+	if s := m.State(); !s.FireAt.Equal(start.Add(50 * time.Millisecond)) {
+		t.Errorf("expected fire at the earliest deadline, got %v after start", s.FireAt.Sub(start))
+	}
+
+	<-m.C()
+	if v, ok := m.Take(); !ok || v != 3 {
+		t.Errorf("expected 3 to be taken by the deadline, got %v, %v", v, ok)
+	}
+
+	// Deadline does not outlive its window
+	m.Push(4)
+	if s := m.State(); s.FireAt.Sub(s.First) != time.Second {
+		t.Errorf("expected regular delay, got %v", s.FireAt.Sub(s.First))
+	}
+}
@@ -0,0 +1,81 @@
+package debounce
+
+// OutputPolicy defines what Chan does with a settled value, when its output channel is full.
+type OutputPolicy int
+
+const (
+	// OutputBlock waits until the consumer receives, stalling Chan meanwhile. This is the default.
+	OutputBlock OutputPolicy = iota
+	// OutputReplace drops the oldest undelivered values to make room, so with the default
+	// buffer of one the consumer always receives the newest settled value.
+	OutputReplace
+	// OutputDropNew drops the settled value, keeping undelivered ones.
+	OutputDropNew
+)
+
+// WithOutputPolicy sets how Chan delivers settled values to a slow consumer and the buffer
+// size of the output channel. Size less than one keeps the default buffer of one value.
+//
+// With OutputReplace and OutputDropNew the Chan goroutine never waits for the consumer,
+// so it keeps receiving values and handling timers while the consumer is busy.
+func WithOutputPolicy(policy OutputPolicy, size int) Option {
+	return func(options *options) {
+		options.output = policy
+		options.outputSize = size
+	}
+}
+
+// deliver sends v to out according to policy. Chan goroutine must be the only sender to out.
+func deliver[T any](out chan T, v T, policy OutputPolicy) {
+	deliverMerged(out, v, policy, nil)
+}
+
+// deliverMerged is deliver, that merges values dropped by OutputReplace into v, if merge is not nil.
+func deliverMerged[T any](out chan T, v T, policy OutputPolicy, merge func(dropped, v T) T) {
+	switch policy {
+	case OutputReplace:
+		for {
+			select {
+			case out <- v:
+				return
+			default:
+			}
+
+			select {
+			case dropped := <-out: // Drop the oldest undelivered value
+				if merge != nil {
+					v = merge(dropped, v)
+				}
+			default: // Consumer received it meanwhile
+			}
+		}
+	case OutputDropNew:
+		select {
+		case out <- v:
+		default:
+		}
+	default:
+		out <- v
+	}
+}
+
+// ClosePolicy defines what Chan does with the pending value, when its input is closed.
+type ClosePolicy int
+
+const (
+	// CloseEmit emits the pending value immediately and closes the output channel. This is the default.
+	CloseEmit ClosePolicy = iota
+	// CloseDrop discards the pending value and closes the output channel.
+	CloseDrop
+	// CloseEmitAfterDelay waits for the pending value to settle, emits it and then closes
+	// the output channel. Pause still holds the value until resumed.
+	CloseEmitAfterDelay
+)
+
+// WithOnClose sets what Chan does with the pending value, when the input channel is closed
+// (or Input is closed).
+func WithOnClose(policy ClosePolicy) Option {
+	return func(options *options) {
+		options.onClose = policy
+	}
+}
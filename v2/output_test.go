@@ -0,0 +1,113 @@
+package debounce_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+func TestOutputPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		policy   debounce.OutputPolicy
+		size     int
+		expected []int
+	}{
+		{"replace", debounce.OutputReplace, 1, []int{5}},
+		{"replace buffered", debounce.OutputReplace, 2, []int{4, 5}},
+		{"drop new", debounce.OutputDropNew, 1, []int{1}},
+		{"drop new buffered", debounce.OutputDropNew, 3, []int{1, 2, 3}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			in := make(chan int)
+			control := &debounce.Control{}
+			out := debounce.Chan(in, debounce.WithLimit(1), debounce.WithControl(control), debounce.WithOutputPolicy(tc.policy, tc.size))
+
+			// Every value is emitted right away, but nobody receives them yet
+			for i := 1; i <= 5; i++ {
+				in <- i
+			}
+			waitState(control, func(s debounce.State) bool { return s.Fires == 5 })
+
+			close(in)
+			result := collect(out, 500*time.Millisecond)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestOutputPolicy_BlockBuffered(t *testing.T) {
+	in := make(chan int)
+	out := debounce.Chan(in, debounce.WithLimit(1), debounce.WithOutputPolicy(debounce.OutputBlock, 3))
+
+	// Three values are buffered, the fourth one is held by Chan goroutine
+	for i := 1; i <= 4; i++ {
+		in <- i
+	}
+
+	select {
+	case in <- 5:
+		t.Error("expected send to block on full output buffer")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	go func() {
+		in <- 5
+		close(in)
+	}()
+
+	result := collect(out, 500*time.Millisecond)
+	expected := []int{1, 2, 3, 4, 5}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestOnClose(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		policy   debounce.ClosePolicy
+		expected []int
+		delayed  bool
+	}{
+		{"emit", debounce.CloseEmit, []int{2}, false},
+		{"drop", debounce.CloseDrop, nil, false},
+		{"emit after delay", debounce.CloseEmitAfterDelay, []int{2}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			in := make(chan int)
+			out := debounce.Chan(in, debounce.WithDelay(100*time.Millisecond), debounce.WithOnClose(tc.policy))
+
+			start := time.Now()
+			in <- 1
+			in <- 2
+			close(in)
+
+			result := collect(out, 500*time.Millisecond)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+			if elapsed := time.Since(start); (elapsed >= 100*time.Millisecond) != tc.delayed {
+				t.Errorf("expected delayed close to be %v, closed after %v", tc.delayed, elapsed)
+			}
+		})
+	}
+}
+
+func TestOnClose_Input(t *testing.T) {
+	in := debounce.NewInput[int]()
+	out := in.Chan(debounce.WithDelay(50*time.Millisecond), debounce.WithOnClose(debounce.CloseEmitAfterDelay))
+
+	in.Send(1)
+	in.Close()
+
+	result := collect(out, 500*time.Millisecond)
+	expected := []int{1}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,132 @@
+package debounce
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Registry keeps track of named debouncers and serves a debug page listing them,
+// similar to the net/http/pprof pages. Chan and Debouncer instances are registered with WithName.
+//
+//	http.Handle("/debug/debounce", debounce.DefaultRegistry)
+type Registry struct {
+	mu       sync.Mutex
+	controls map[*Control]string
+}
+
+// DefaultRegistry is the Registry used by WithName, unless WithRegistry is specified.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		controls: make(map[*Control]string),
+	}
+}
+
+// WithName registers Chan (or Debouncer) under name in DefaultRegistry, or the registry
+// set with WithRegistry. Names are not required to be unique.
+// It is unregistered automatically, when input channel is closed (or Debouncer is closed).
+func WithName(name string) Option {
+	return func(options *options) {
+		options.name = name
+	}
+}
+
+// WithRegistry sets the Registry used by WithName.
+func WithRegistry(r *Registry) Option {
+	return func(options *options) {
+		options.registry = r
+	}
+}
+
+// Entry describes a registered Chan or Debouncer.
+type Entry struct {
+	Name  string        `json:"name"`
+	Delay time.Duration `json:"delay"`
+	Limit int           `json:"limit,omitempty"` // Zero if there is no limit
+	State State         `json:"state"`
+}
+
+// Entries returns descriptions of all registered debouncers, sorted by name.
+func (r *Registry) Entries() []Entry {
+	r.mu.Lock()
+	entries := make([]Entry, 0, len(r.controls))
+	for c, name := range r.controls {
+		entries = append(entries, c.entry(name))
+	}
+	r.mu.Unlock()
+
+	slices.SortStableFunc(entries, func(a, b Entry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return entries
+}
+
+// ServeHTTP renders registered debouncers as plain text, or as JSON if
+// the request has format=json query parameter.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	entries := r.Entries()
+
+	if req.FormValue("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(entries)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "debounce: %d debouncers\n\n", len(entries))
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tDELAY\tLIMIT\tPENDING\tPAUSED\tCALLS\tFIRST\tFIRE AT\tFIRES")
+	for _, e := range entries {
+		limit := "-"
+		if e.Limit != 0 {
+			limit = strconv.Itoa(e.Limit)
+		}
+
+		fmt.Fprintf(tw, "%s\t%v\t%s\t%t\t%t\t%d\t%s\t%s\t%d\n",
+			e.Name, e.Delay, limit,
+			e.State.Pending, e.State.Paused, e.State.Calls,
+			formatTime(e.State.First), formatTime(e.State.FireAt), e.State.Fires)
+	}
+	_ = tw.Flush()
+}
+
+func (r *Registry) register(name string, c *Control) {
+	r.mu.Lock()
+	r.controls[c] = name
+	r.mu.Unlock()
+}
+
+func (r *Registry) unregister(c *Control) {
+	r.mu.Lock()
+	delete(r.controls, c)
+	r.mu.Unlock()
+}
+
+func (c *Control) entry(name string) Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Entry{
+		Name:  name,
+		Delay: c.delay,
+		Limit: c.limit,
+		State: c.state,
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339Nano)
+}
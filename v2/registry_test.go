@@ -0,0 +1,81 @@
+package debounce_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+// helper to wait until registry has expected number of entries
+func waitEntries(registry *debounce.Registry, n int) []debounce.Entry {
+	deadline := time.Now().Add(time.Second)
+	for {
+		entries := registry.Entries()
+		if len(entries) == n || time.Now().After(deadline) {
+			return entries
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	registry := debounce.NewRegistry()
+
+	in := make(chan int)
+	_ = debounce.Chan(in, debounce.WithDelay(time.Second), debounce.WithLimit(10), debounce.WithName("b"), debounce.WithRegistry(registry))
+	debouncer := debounce.New(debounce.WithDelay(time.Minute), debounce.WithName("a"), debounce.WithRegistry(registry))
+
+	in <- 1
+
+	entries := waitEntries(registry, 2)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+	if entries[0].Name != "a" || entries[0].Delay != time.Minute || entries[0].Limit != 0 {
+		t.Errorf("unexpected entry for a: %+v", entries[0])
+	}
+	if entries[1].Name != "b" || entries[1].Delay != time.Second || entries[1].Limit != 10 {
+		t.Errorf("unexpected entry for b: %+v", entries[1])
+	}
+
+	close(in)
+	debouncer.Close()
+
+	if entries := waitEntries(registry, 0); len(entries) != 0 {
+		t.Errorf("expected no entries after close, got %+v", entries)
+	}
+}
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	registry := debounce.NewRegistry()
+	debouncer := debounce.New(debounce.WithDelay(time.Second), debounce.WithLimit(5), debounce.WithName("cache-rebuild"), debounce.WithRegistry(registry))
+	defer debouncer.Close()
+
+	debouncer.Do(func() {})
+	for debouncer.State().Calls != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	rec := httptest.NewRecorder()
+	registry.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/debounce", nil))
+
+	body := rec.Body.String()
+	if !strings.Contains(body, "debounce: 1 debouncers") || !strings.Contains(body, "cache-rebuild") {
+		t.Errorf("unexpected text page:\n%s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	registry.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/debounce?format=json", nil))
+
+	var entries []debounce.Entry
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+		t.Fatalf("failed to decode JSON page: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "cache-rebuild" || entries[0].Limit != 5 || entries[0].State.Calls != 1 {
+		t.Errorf("unexpected JSON page: %+v", entries)
+	}
+}
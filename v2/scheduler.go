@@ -0,0 +1,175 @@
+package debounce
+
+import (
+	"sync"
+	"time"
+)
+
+// wheelSize is the number of slots in the Scheduler timing wheel.
+const wheelSize = 512
+
+// Scheduler is a hashed timing wheel, that drives delay timers of many Chan, Debouncer and Machine
+// instances from a single goroutine. Share it with WithScheduler, when there are too many
+// of them to give each one its own runtime timer.
+//
+// Scheduler removes only timers: every Chan and Debouncer still runs its own goroutine.
+// For a million live debouncers (e.g. one per connected device) use Machine with a shared
+// Scheduler, which needs neither goroutines nor runtime timers, or ChanBy, which debounces
+// all keys of a stream in one goroutine.
+//
+// Scheduling and rescheduling take O(1) time. Timers fire on tick boundaries:
+// never earlier than requested, but up to one tick later.
+type Scheduler struct {
+	mu    sync.Mutex
+	tick  time.Duration
+	start time.Time
+	ticks int64                  // Number of processed ticks
+	slots [wheelSize]*wheelTimer // Heads of timer lists, one per slot
+
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewScheduler creates a Scheduler with the given tick resolution and starts its goroutine.
+// It panics, if tick is not positive.
+func NewScheduler(tick time.Duration) *Scheduler {
+	if tick <= 0 {
+		panic("debounce: non-positive tick for NewScheduler")
+	}
+
+	s := &Scheduler{
+		tick:  tick,
+		start: time.Now(),
+		done:  make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// WithScheduler makes Chan use s for its delay instead of its own runtime timer.
+// It has effect only when Chan is created.
+func WithScheduler(s *Scheduler) Option {
+	return func(options *options) {
+		options.scheduler = s
+	}
+}
+
+// Stop stops the Scheduler goroutine. Scheduled timers will never fire.
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+func (s *Scheduler) run() {
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+
+	var expired []*wheelTimer
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			expired = s.advance(time.Now(), expired[:0])
+
+			// Callbacks are executed outside mutex, so they can reschedule timers
+			for i, t := range expired {
+				t.f()
+				expired[i] = nil
+			}
+		}
+	}
+}
+
+// advance processes all ticks up to now and appends expired timers to expired.
+func (s *Scheduler) advance(now time.Time, expired []*wheelTimer) []*wheelTimer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	target := int64(now.Sub(s.start) / s.tick)
+	for s.ticks < target {
+		s.ticks++
+		for t := s.slots[s.ticks%wheelSize]; t != nil; {
+			next := t.next
+			if t.rounds > 0 {
+				t.rounds--
+			} else {
+				s.remove(t)
+				expired = append(expired, t)
+			}
+			t = next
+		}
+	}
+	return expired
+}
+
+// afterFunc creates a stopped timer, that calls f on the Scheduler goroutine.
+// f must not block.
+func (s *Scheduler) afterFunc(f func()) *wheelTimer {
+	return &wheelTimer{
+		s:    s,
+		f:    f,
+		slot: -1,
+	}
+}
+
+func (s *Scheduler) insert(t *wheelTimer, d time.Duration) {
+	// Timer fires on the first tick boundary after d elapses
+	ticks := max(int64((time.Since(s.start)+d+s.tick-1)/s.tick)-s.ticks, 1)
+
+	t.slot = int((s.ticks + ticks) % wheelSize)
+	t.rounds = (ticks - 1) / wheelSize
+	t.prev = nil
+	t.next = s.slots[t.slot]
+	if t.next != nil {
+		t.next.prev = t
+	}
+	s.slots[t.slot] = t
+}
+
+func (s *Scheduler) remove(t *wheelTimer) {
+	if t.prev != nil {
+		t.prev.next = t.next
+	} else {
+		s.slots[t.slot] = t.next
+	}
+	if t.next != nil {
+		t.next.prev = t.prev
+	}
+	t.prev, t.next = nil, nil
+	t.slot = -1
+}
+
+// wheelTimer is a timer driven by Scheduler. It mirrors Reset and Stop of time.Timer.
+type wheelTimer struct {
+	s      *Scheduler
+	f      func()
+	slot   int   // Slot in the wheel, -1 if timer is not scheduled
+	rounds int64 // Number of full wheel turns left before timer expires
+
+	prev, next *wheelTimer
+}
+
+func (t *wheelTimer) Reset(d time.Duration) bool {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+
+	active := t.slot >= 0
+	if active {
+		t.s.remove(t)
+	}
+	t.s.insert(t, d)
+	return active
+}
+
+func (t *wheelTimer) Stop() bool {
+	t.s.mu.Lock()
+	defer t.s.mu.Unlock()
+
+	active := t.slot >= 0
+	if active {
+		t.s.remove(t)
+	}
+	return active
+}
@@ -0,0 +1,131 @@
+package debounce_test
+
+import (
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+func TestScheduler_Chan(t *testing.T) {
+	s := debounce.NewScheduler(time.Millisecond)
+	defer s.Stop()
+
+	in := make(chan int)
+	out := debounce.Chan(in, debounce.WithDelay(100*time.Millisecond), debounce.WithScheduler(s))
+
+	go func() {
+		in <- 1
+		time.Sleep(50 * time.Millisecond)
+		in <- 2
+		time.Sleep(150 * time.Millisecond)
+		in <- 3
+		close(in)
+	}()
+
+	expected := []int{2, 3}
+	result := collect(out, 1*time.Second)
+	if !slices.Equal(expected, result) {
+		t.Errorf("expected result = %v, got %v", expected, result)
+	}
+}
+
+func TestScheduler_Debouncers(t *testing.T) {
+	s := debounce.NewScheduler(time.Millisecond)
+	defer s.Stop()
+
+	var counter int32
+	f := func() { atomic.AddInt32(&counter, 1) }
+
+	debouncers := make([]*debounce.Debouncer, 100)
+	for i := range debouncers {
+		debouncers[i] = debounce.New(debounce.WithDelay(20*time.Millisecond), debounce.WithScheduler(s))
+		defer debouncers[i].Close()
+	}
+
+	for range 3 {
+		for _, d := range debouncers {
+			d.Do(f)
+		}
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if c := atomic.LoadInt32(&counter); c != int32(len(debouncers)) {
+		t.Errorf("expected counter = %d, got %d", len(debouncers), c)
+	}
+}
+
+// BenchmarkScheduler_LiveMachines measures memory and per-push cost with 10^6 live Machines,
+// each holding a pending value, with runtime timers and with a shared Scheduler.
+func BenchmarkScheduler_LiveMachines(b *testing.B) {
+	const count = 1_000_000
+
+	// Machines are created once per sub-benchmark, as it is called for every b.N
+	setup := func(opts ...debounce.Option) (machines []*debounce.Machine[int], bytes float64) {
+		var m1, m2 runtime.MemStats
+
+		runtime.GC()
+		runtime.ReadMemStats(&m1)
+
+		machines = make([]*debounce.Machine[int], count)
+		for i := range machines {
+			machines[i] = debounce.NewMachine[int](append(opts, debounce.WithDelay(time.Minute))...)
+			machines[i].Push(i)
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&m2)
+
+		return machines, float64(m2.HeapAlloc-m1.HeapAlloc) / count
+	}
+
+	run := func(b *testing.B, machines []*debounce.Machine[int], bytes float64) {
+		b.ResetTimer()
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			machines[i%count].Push(i)
+		}
+
+		b.ReportMetric(bytes, "bytes/machine")
+	}
+
+	var timers []*debounce.Machine[int]
+	var timersBytes float64
+
+	b.Run("timer", func(b *testing.B) {
+		if timers == nil {
+			timers, timersBytes = setup()
+		}
+		run(b, timers, timersBytes)
+	})
+	for _, m := range timers {
+		m.Stop()
+	}
+	timers = nil
+
+	s := debounce.NewScheduler(time.Millisecond)
+	defer s.Stop()
+
+	var scheduled []*debounce.Machine[int]
+	var scheduledBytes float64
+
+	b.Run("scheduler", func(b *testing.B) {
+		if scheduled == nil {
+			scheduled, scheduledBytes = setup(debounce.WithScheduler(s))
+		}
+		run(b, scheduled, scheduledBytes)
+	})
+}
+
+func TestScheduler_Tick(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected NewScheduler to panic with non-positive tick")
+		}
+	}()
+	debounce.NewScheduler(0)
+}
@@ -0,0 +1,13 @@
+package debounce
+
+import "time"
+
+// State is a snapshot of a running Chan or Debouncer, returned by Control.State.
+type State struct {
+	Pending bool      `json:"pending"` // Whether a value is waiting to be emitted
+	Paused  bool      `json:"paused"`  // Whether emission is paused
+	Calls   int       `json:"calls"`   // Number of values coalesced into the pending one
+	First   time.Time `json:"first"`   // Time the first pending value was received, zero if nothing is pending
+	FireAt  time.Time `json:"fire_at"` // Time the pending value is scheduled to be emitted, zero if not scheduled
+	Fires   uint64    `json:"fires"`   // Total number of emitted values so far
+}
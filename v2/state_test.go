@@ -0,0 +1,69 @@
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+// helper to wait until Chan goroutine publishes expected state
+func waitState(control *debounce.Control, cond func(debounce.State) bool) debounce.State {
+	deadline := time.Now().Add(time.Second)
+	for {
+		s := control.State()
+		if cond(s) || time.Now().After(deadline) {
+			return s
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestControl_State(t *testing.T) {
+	var control debounce.Control
+	in := make(chan int)
+	out := debounce.Chan(in, debounce.WithDelay(50*time.Millisecond), debounce.WithControl(&control))
+
+	start := time.Now()
+	in <- 1
+	in <- 2
+
+	s := waitState(&control, func(s debounce.State) bool { return s.Calls == 2 })
+	if !s.Pending || s.Calls != 2 || s.Fires != 0 {
+		t.Errorf("expected 2 pending values without fires, got %+v", s)
+	}
+	if s.First.Before(start) || s.FireAt.Before(s.First.Add(50*time.Millisecond)) {
+		t.Errorf("expected First >= %v and FireAt >= First + 50ms, got %+v", start, s)
+	}
+
+	<-out
+
+	s = waitState(&control, func(s debounce.State) bool { return s.Fires == 1 })
+	if s.Pending || s.Calls != 0 || s.Fires != 1 || !s.First.IsZero() || !s.FireAt.IsZero() {
+		t.Errorf("expected 1 fire without pending values, got %+v", s)
+	}
+
+	control.Pause()
+	in <- 3
+
+	s = waitState(&control, func(s debounce.State) bool { return s.Pending })
+	if !s.Paused || !s.FireAt.IsZero() {
+		t.Errorf("expected paused pending value without schedule, got %+v", s)
+	}
+
+	close(in)
+	<-out
+}
+
+func TestDebouncer_State(t *testing.T) {
+	debouncer := debounce.New(debounce.WithDelay(10 * time.Millisecond))
+	defer debouncer.Close()
+
+	debouncer.Do(func() {})
+	debouncer.Do(func() {})
+
+	time.Sleep(50 * time.Millisecond)
+	if s := debouncer.State(); s.Pending || s.Fires != 1 {
+		t.Errorf("expected 1 fire without pending functions, got %+v", s)
+	}
+}
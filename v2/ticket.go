@@ -0,0 +1,208 @@
+package debounce
+
+// ticketState is the state of a submission made with Debouncer.Submit.
+type ticketState int
+
+const (
+	ticketPending ticketState = iota
+	ticketCancelled
+	ticketExecuted
+	ticketSuperseded
+)
+
+// Ticket is a handle to a single submission made with Debouncer.Submit.
+type Ticket struct {
+	d     *Debouncer
+	seq   uint64      // Sequence number of the submission, set under d.mu
+	state ticketState // Guarded by d.mu
+}
+
+// entry is a submission received by the Chan goroutine, but not yet executed.
+type entry struct {
+	seq    uint64
+	f      func()
+	ticket *Ticket // Nil for Do and DoBy
+}
+
+// Submit submits f like Do and returns a Ticket, that can withdraw the submission.
+//
+// With WithSlotInput submissions are coalesced before Debouncer sees them,
+// so the returned Ticket can not cancel anything.
+func (d *Debouncer) Submit(f func()) *Ticket {
+	t := &Ticket{d: d}
+	if d.input != nil {
+		t.state = ticketSuperseded
+		d.input.Send(f)
+	} else {
+		d.send(submission{f: f}, t)
+	}
+	return t
+}
+
+// Cancel withdraws the submission, if it is still the pending one: the function submitted
+// before it in the same window becomes pending again, or the window is cleared, if there is
+// no such function. It reports whether the submission was cancelled. Cancel returns false,
+// if the function was already executed or another submission replaced it.
+//
+// Cancelled submission is not counted by WithLimit. Cancel returns after the window is updated,
+// so State reflects it and later submissions are counted after it.
+func (t *Ticket) Cancel() bool {
+	d := t.d
+
+	// Submissions wait, so they are not counted before the window is updated
+	d.sendMu.Lock()
+	defer d.sendMu.Unlock()
+
+	if !d.cancel(t) {
+		return false
+	}
+
+	d.control.wait(withWithdrawal())
+	return true
+}
+
+// cancel marks t as cancelled and queues it for withdrawal, if it is the pending submission.
+func (d *Debouncer) cancel(t *Ticket) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if t.state != ticketPending {
+		return false
+	}
+
+	// Only the latest submission, that is not cancelled, is pending
+	for i := len(d.history) - 1; i >= 0; i-- {
+		e := d.history[i]
+		if e.ticket != nil && e.ticket.state == ticketCancelled {
+			continue
+		}
+		if e.ticket != t {
+			return false
+		}
+		break
+	}
+
+	t.state = ticketCancelled
+	d.withdrawn = append(d.withdrawn, t)
+	return true
+}
+
+// withdraw is called by the Chan goroutine, when cancelled tickets are queued. It tells how to
+// update the pending window of submissions from first to last, if there is one: remove cancelled
+// submissions or drop the window, if nothing remains in it.
+func (d *Debouncer) withdraw(first, last submission, pending bool) (n int, drop bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	withdrawn := d.withdrawn
+	d.withdrawn = nil
+	if !pending {
+		return 0, false
+	}
+
+	for _, t := range withdrawn {
+		// Windows emitted before the cancellation are handled by settle
+		if t.seq >= first.seq && t.seq <= last.seq {
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, false
+	}
+
+	for _, e := range d.history {
+		if e.seq >= first.seq && e.seq <= last.seq && (e.ticket == nil || e.ticket.state != ticketCancelled) {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+// withWithdrawal makes the Chan goroutine withdraw cancelled submissions from the pending window.
+func withWithdrawal() Option {
+	return func(options *options) {
+		options.withdraw = true
+	}
+}
+
+// apply applies withdrawal requested with the options to the pending window, that started with first.
+func (h hooks[T]) apply(m *Machine[T], first T) {
+	if !m.options.withdraw {
+		return
+	}
+	m.options.withdraw = false
+	if h.withdraw == nil {
+		return
+	}
+
+	n, drop := h.withdraw(first, m.value, m.pending)
+	if drop {
+		m.drop()
+	} else {
+		m.count -= n
+	}
+}
+
+// send records s in the history and sends it to the Chan goroutine.
+// Sends are serialized, so history is in the order of receiving.
+func (d *Debouncer) send(s submission, t *Ticket) {
+	d.sendMu.Lock()
+	defer d.sendMu.Unlock()
+
+	d.mu.Lock()
+	d.seq++
+	s.seq = d.seq
+	if t != nil {
+		t.seq = s.seq
+	}
+	e := entry{seq: s.seq, f: s.f, ticket: t}
+	if n := len(d.history); t == nil && n > 0 && d.history[n-1].ticket == nil {
+		// Submission without ticket can not be cancelled, so the previous one
+		// without ticket will never be restored
+		d.history[n-1] = e
+	} else {
+		d.history = append(d.history, e)
+	}
+	d.mu.Unlock()
+
+	d.inputCh <- s
+}
+
+// settle returns the function to execute, when the Chan goroutine emits s.
+// It is the latest submission up to s, that is not cancelled.
+func (d *Debouncer) settle(s submission) func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Window holds submissions received up to s
+	n := 0
+	for n < len(d.history) && d.history[n].seq <= s.seq {
+		n++
+	}
+	window := d.history[:n]
+
+	// Submission without ticket, that replaced its predecessor in history, is executed as is
+	f, executed := s.f, (*Ticket)(nil)
+	if n > 0 && window[n-1].seq == s.seq {
+		f = nil
+		for i := n - 1; i >= 0; i-- {
+			if t := window[i].ticket; t == nil || t.state != ticketCancelled {
+				f, executed = window[i].f, t
+				break
+			}
+		}
+	}
+
+	for i := range window {
+		if t := window[i].ticket; t != nil && t.state == ticketPending {
+			t.state = ticketSuperseded
+			if t == executed {
+				t.state = ticketExecuted
+			}
+		}
+		window[i] = entry{}
+	}
+	d.history = d.history[n:]
+
+	return f
+}
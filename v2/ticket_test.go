@@ -0,0 +1,140 @@
+package debounce_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/floatdrop/debounce/v2"
+)
+
+func TestTicket_Cancel(t *testing.T) {
+	t.Run("restores previous function", func(t *testing.T) {
+		done := make(chan string, 2)
+		debouncer := debounce.New(debounce.WithDelay(50 * time.Millisecond))
+		defer debouncer.Close()
+
+		debouncer.Do(func() { done <- "first" })
+		ticket := debouncer.Submit(func() { done <- "second" })
+		if !ticket.Cancel() {
+			t.Fatal("expected pending submission to be cancelled")
+		}
+		if ticket.Cancel() {
+			t.Error("expected second Cancel to return false")
+		}
+
+		select {
+		case got := <-done:
+			if got != "first" {
+				t.Errorf("expected first function to be executed, got %s", got)
+			}
+		case <-time.After(200 * time.Millisecond):
+			t.Fatal("expected previous function to be executed")
+		}
+	})
+
+	t.Run("clears window", func(t *testing.T) {
+		done := make(chan struct{}, 1)
+		debouncer := debounce.New(debounce.WithDelay(30 * time.Millisecond))
+		defer debouncer.Close()
+
+		ticket := debouncer.Submit(func() { done <- struct{}{} })
+		if !ticket.Cancel() {
+			t.Fatal("expected pending submission to be cancelled")
+		}
+
+		select {
+		case <-done:
+			t.Error("expected cancelled function not to be executed")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("state", func(t *testing.T) {
+		debouncer := debounce.New(debounce.WithDelay(100 * time.Millisecond))
+		defer debouncer.Close()
+
+		ticket := debouncer.Submit(func() {})
+		ticket.Cancel()
+
+		if s := debouncer.State(); s.Pending || s.Calls != 0 || !s.FireAt.IsZero() {
+			t.Errorf("expected cleared window after Cancel, got %+v", s)
+		}
+
+		time.Sleep(150 * time.Millisecond)
+		if s := debouncer.State(); s.Fires != 0 {
+			t.Errorf("expected no executions, got %d", s.Fires)
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		done := make(chan time.Time, 1)
+		debouncer := debounce.New(debounce.WithDelay(100*time.Millisecond), debounce.WithLimit(2))
+		defer debouncer.Close()
+
+		debouncer.Submit(func() {}).Cancel()
+		start := time.Now()
+		debouncer.Do(func() { done <- time.Now() })
+
+		// Cancelled submission is not counted, so the limit is not reached
+		select {
+		case fired := <-done:
+			if elapsed := fired.Sub(start); elapsed < 100*time.Millisecond {
+				t.Errorf("expected execution after the delay, got after %v", elapsed)
+			}
+		case <-time.After(300 * time.Millisecond):
+			t.Fatal("expected function to be executed")
+		}
+	})
+
+	t.Run("limit keeps previous", func(t *testing.T) {
+		debouncer := debounce.New(debounce.WithDelay(100*time.Millisecond), debounce.WithLimit(3))
+		defer debouncer.Close()
+
+		debouncer.Do(func() {})
+		debouncer.Submit(func() {}).Cancel()
+
+		if s := debouncer.State(); !s.Pending || s.Calls != 1 {
+			t.Errorf("expected window with the previous submission, got %+v", s)
+		}
+	})
+
+	t.Run("superseded", func(t *testing.T) {
+		done := make(chan string, 1)
+		debouncer := debounce.New(debounce.WithDelay(30 * time.Millisecond))
+		defer debouncer.Close()
+
+		ticket := debouncer.Submit(func() { done <- "first" })
+		debouncer.Do(func() { done <- "second" })
+		if ticket.Cancel() {
+			t.Error("expected Cancel of superseded submission to return false")
+		}
+
+		if got := <-done; got != "second" {
+			t.Errorf("expected second function to be executed, got %s", got)
+		}
+	})
+
+	t.Run("executed", func(t *testing.T) {
+		done := make(chan struct{}, 1)
+		debouncer := debounce.New(debounce.WithDelay(10 * time.Millisecond))
+		defer debouncer.Close()
+
+		ticket := debouncer.Submit(func() { done <- struct{}{} })
+		<-done
+		if ticket.Cancel() {
+			t.Error("expected Cancel of executed submission to return false")
+		}
+	})
+
+	t.Run("slot input", func(t *testing.T) {
+		done := make(chan struct{}, 1)
+		debouncer := debounce.New(debounce.WithDelay(10*time.Millisecond), debounce.WithSlotInput())
+		defer debouncer.Close()
+
+		ticket := debouncer.Submit(func() { done <- struct{}{} })
+		if ticket.Cancel() {
+			t.Error("expected Cancel with slot input to return false")
+		}
+		<-done
+	})
+}